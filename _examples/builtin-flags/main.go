@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -30,7 +31,11 @@ func main() {
 	})
 
 	// 解析并执行
+	// 内置标志 (--help/--version) 输出后返回哨兵错误, 视为正常结束
 	err := root.ParseAndRoute(os.Args[1:])
+	if errors.Is(err, types.ErrHelp) || errors.Is(err, types.ErrVersion) || errors.Is(err, types.ErrCompletion) {
+		return
+	}
 	if err != nil {
 		fmt.Println("错误:", err.Error())
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -66,6 +67,10 @@ func main() {
 
 	// 解析并执行
 	if err := myCmd.ParseAndRoute(os.Args[1:]); err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, qflag.ErrHelp) || errors.Is(err, qflag.ErrVersion) || errors.Is(err, qflag.ErrCompletion) {
+			return
+		}
 		fmt.Printf("执行失败: %v\n", err)
 		os.Exit(1)
 	}
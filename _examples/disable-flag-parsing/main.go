@@ -16,6 +16,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -169,6 +170,10 @@ func main() {
 
 	// 执行
 	if err := root.ParseAndRoute(os.Args[1:]); err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, qflag.ErrHelp) || errors.Is(err, qflag.ErrVersion) || errors.Is(err, qflag.ErrCompletion) {
+			return
+		}
 		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
 		os.Exit(1)
 	}
@@ -10,6 +10,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -47,6 +48,10 @@ func main() {
 
 	// 解析命令行参数
 	if err := root.Parse(nil); err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, types.ErrHelp) || errors.Is(err, types.ErrVersion) || errors.Is(err, types.ErrCompletion) {
+			return
+		}
 		fmt.Printf("参数解析失败: %v\n", err)
 		return
 	}
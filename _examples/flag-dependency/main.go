@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -67,6 +68,10 @@ func main() {
 	// 解析参数
 	err := app.Parse(os.Args[1:])
 	if err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, qflag.ErrHelp) || errors.Is(err, qflag.ErrVersion) || errors.Is(err, qflag.ErrCompletion) {
+			return
+		}
 		fmt.Printf("参数错误: %v\n", err)
 		os.Exit(1)
 	}
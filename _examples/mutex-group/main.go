@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -58,6 +59,10 @@ func main() {
 	// 解析参数
 	err := app.Parse(os.Args[1:])
 	if err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, types.ErrHelp) || errors.Is(err, types.ErrVersion) || errors.Is(err, types.ErrCompletion) {
+			return
+		}
 		fmt.Printf("参数错误: %v\n", err)
 		os.Exit(1)
 	}
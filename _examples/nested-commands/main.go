@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -60,6 +61,10 @@ func main() {
 	}
 
 	if err := mainCmd.ParseAndRoute(os.Args[1:]); err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, qflag.ErrHelp) || errors.Is(err, qflag.ErrVersion) || errors.Is(err, qflag.ErrCompletion) {
+			return
+		}
 		fmt.Printf("错误: %v\n", err)
 		os.Exit(1)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -241,6 +242,10 @@ func main() {
 
 	// 解析并路由到子命令
 	if err := qflag.ParseAndRoute(); err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, qflag.ErrHelp) || errors.Is(err, qflag.ErrVersion) || errors.Is(err, qflag.ErrCompletion) {
+			return
+		}
 		fmt.Printf("参数解析错误: %v\n", err)
 		fmt.Println("\n使用示例:")
 		fmt.Println("  简单示例: required-groups-demo simple --server-host localhost --server-port 8080")
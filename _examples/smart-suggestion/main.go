@@ -13,6 +13,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -85,6 +86,10 @@ func main() {
 
 	// 解析并执行命令
 	if err := root.ParseAndRoute(os.Args[1:]); err != nil {
+		// --help/--version/--completion 已处理, 正常结束
+		if errors.Is(err, qflag.ErrHelp) || errors.Is(err, qflag.ErrVersion) || errors.Is(err, qflag.ErrCompletion) {
+			return
+		}
 		// 打印错误信息（智能纠错功能已格式化）
 		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
 		os.Exit(1)
@@ -1,9 +1,15 @@
 package qflag
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"gitee.com/MM-Q/qflag/internal/cmd"
 	"gitee.com/MM-Q/qflag/internal/completion"
 	"gitee.com/MM-Q/qflag/internal/types"
 )
@@ -17,7 +23,7 @@ func TestCompletionSpeed(t *testing.T) {
 	cmd := createTestCmd()
 
 	// 测试不同shell类型的补全脚本生成速度
-	shells := []string{types.BashShell, types.PwshShell, types.PowershellShell}
+	shells := []string{types.BashShell, types.ZshShell, types.FishShell, types.PwshShell, types.PowershellShell}
 
 	for _, shell := range shells {
 		t.Run(shell, func(t *testing.T) {
@@ -58,7 +64,7 @@ func BenchmarkCompletionSpeed(b *testing.B) {
 	cmd := createTestCmd()
 
 	// 测试不同shell类型的基准性能
-	shells := []string{types.BashShell, types.PwshShell, types.PowershellShell}
+	shells := []string{types.BashShell, types.ZshShell, types.FishShell, types.PwshShell, types.PowershellShell}
 
 	for _, shell := range shells {
 		b.Run(shell, func(b *testing.B) {
@@ -96,3 +102,469 @@ func TestGenerateFullCompletionScript(t *testing.T) {
 
 	cmd.PrintHelp()
 }
+
+// TestCompletionPersistentFlags 测试持久标志出现在所有子孙命令的补全中
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionPersistentFlags(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.String("config", "c", "配置文件", "")
+	if err := root.MarkPersistent("config"); err != nil {
+		t.Fatalf("MarkPersistent error: %v", err)
+	}
+	server := cmd.NewCmd("server", "", types.ContinueOnError)
+	start := cmd.NewCmd("start", "", types.ContinueOnError)
+	if err := server.AddSubCmds(start); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	// 静态补全: 每个子孙命令路径都包含持久标志
+	for _, shell := range []string{types.BashShell, types.PwshShell} {
+		script, err := completion.GenerateStatic(root, shell)
+		if err != nil {
+			t.Fatalf("GenerateStatic(%s) error: %v", shell, err)
+		}
+		for _, path := range []string{"/server/", "/server/start/"} {
+			if !strings.Contains(script, path) {
+				t.Errorf("%s script missing path %s", shell, path)
+			}
+		}
+		if strings.Count(script, "--config") < 3 {
+			t.Errorf("%s script should offer --config for every command", shell)
+		}
+	}
+
+	// 动态补全: 候选项包含持久标志
+	candidates, err := completion.GetCandidates(root, "/server/start/")
+	if err != nil {
+		t.Fatalf("GetCandidates error: %v", err)
+	}
+	found := false
+	for _, c := range candidates {
+		if c == "--config" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("dynamic candidates should contain --config, got %v", candidates)
+	}
+}
+
+// TestCompletionNegatableFlags 测试取反形式出现在补全中
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionNegatableFlags(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.Bool("color", "", "彩色输出", true).SetNegatable(true)
+	root.Bool("debug", "", "调试模式", false)
+
+	for _, shell := range []string{types.BashShell, types.PwshShell} {
+		script, err := completion.GenerateStatic(root, shell)
+		if err != nil {
+			t.Fatalf("GenerateStatic(%s) error: %v", shell, err)
+		}
+		if !strings.Contains(script, "--no-color") {
+			t.Errorf("%s script should offer --no-color", shell)
+		}
+		if strings.Contains(script, "--no-debug") {
+			t.Errorf("%s script should not offer --no-debug", shell)
+		}
+	}
+
+	candidates, err := completion.GetCandidates(root, "/")
+	if err != nil {
+		t.Fatalf("GetCandidates error: %v", err)
+	}
+	if !slices.Contains(candidates, "--no-color") {
+		t.Errorf("dynamic candidates should contain --no-color, got %v", candidates)
+	}
+}
+
+// TestCompletionCountFlag 测试计数标志在补全中不需要值
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionCountFlag(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.Count("verbose", "v", "详细级别", 0)
+
+	script, err := completion.GenerateStatic(root, types.BashShell)
+	if err != nil {
+		t.Fatalf("GenerateStatic error: %v", err)
+	}
+	if !strings.Contains(script, `["/|--verbose"]="none|count"`) {
+		t.Errorf("bash script should mark --verbose as taking no value")
+	}
+}
+
+// TestCompletionZsh 测试 zsh 静态和动态补全脚本
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionZsh(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.String("output", "o", "Output file", "")
+	root.Enum("mode", "m", "Run mode", "fast", []string{"safe mode", "fast"})
+	server := cmd.NewCmd("server", "s", types.ContinueOnError)
+	server.SetDesc("Manage the server")
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	script, err := completion.GenerateStatic(root, types.ZshShell)
+	if err != nil {
+		t.Fatalf("GenerateStatic error: %v", err)
+	}
+	funcName := completion.ZshFuncName(filepath.Base(os.Args[0]))
+	for _, want := range []string{
+		"#compdef ",
+		"--output:Output file\n-m:Run mode",
+		"server:Manage the server",
+		"  '/server/' '",
+		"  '/s/' '",
+		"  '/|--output' 'required|string'",
+		// 枚举值按声明顺序输出
+		"  '/|--mode' 'safe mode\nfast'",
+		"compdef " + funcName + " ",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("zsh script missing %q", want)
+		}
+	}
+
+	dynamic, err := completion.GenerateDynamic(root, types.ZshShell)
+	if err != nil {
+		t.Fatalf("GenerateDynamic error: %v", err)
+	}
+	if !strings.Contains(dynamic, "__complete json") || !strings.Contains(dynamic, funcName+"()") {
+		t.Errorf("zsh dynamic script should call __complete json from %s", funcName)
+	}
+}
+
+// TestCompletionFish 测试Fish补全脚本的生成
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionFish(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.String("output", "o", "Output file", "")
+	root.Enum("mode", "m", "Run mode", "fast", []string{"safe mode", "fast"})
+	root.Bool("verbose", "", "Verbose output", false)
+	server := cmd.NewCmd("server", "s", types.ContinueOnError)
+	server.SetDesc("Manage the server")
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	script, err := completion.GenerateStatic(root, types.FishShell)
+	if err != nil {
+		t.Fatalf("GenerateStatic error: %v", err)
+	}
+	prog := filepath.Base(os.Args[0])
+	cond := "_using_context \\'/\\'' "
+	for _, want := range []string{
+		"_contexts '/server/'\n",
+		"_contexts '/s/'\n",
+		cond + "-f -a 'server' -d 'Manage the server'\n",
+		cond + "-f -a 's' -d 'Manage the server'\n",
+		cond + "-l output -r -F -d 'Output file'\n",
+		// 枚举值按声明顺序输出
+		cond + "-s m -x -a 'safe\\\\ mode fast' -d 'Run mode'\n",
+		cond + "-l verbose -d 'Verbose output'\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("fish script missing %q", want)
+		}
+	}
+
+	if !strings.Contains(script, "complete -c "+prog+" -n ") {
+		t.Errorf("fish script should register completions for %s", prog)
+	}
+
+	dynamic, err := completion.GenerateDynamic(root, types.FishShell)
+	if err != nil {
+		t.Fatalf("GenerateDynamic error: %v", err)
+	}
+	if !strings.Contains(dynamic, prog+" __complete json") || !strings.Contains(dynamic, "complete -c "+prog+" -f -a") {
+		t.Errorf("fish dynamic script should call %s __complete json", prog)
+	}
+}
+
+// TestCompletionFunc 测试标志和位置参数的补全函数通过 all 指令输出候选项和补全指令
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionFunc(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	env := root.String("env", "e", "Environment", "dev")
+	if err := root.MarkPersistent("env"); err != nil {
+		t.Fatalf("MarkPersistent error: %v", err)
+	}
+	deploy := cmd.NewCmd("deploy", "", types.ContinueOnError)
+	if err := root.AddSubCmds(deploy); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	// 标志值: 候选项依赖已解析的 --env
+	deploy.String("namespace", "n", "Namespace", "").SetCompletionFunc(func(c types.Command, prefix string) types.CompletionResult {
+		return types.CompletionResult{
+			Candidates: []types.CompletionCandidate{
+				{Value: env.Get() + "-api", Desc: "API services"},
+				{Value: env.Get() + "-web"},
+				{Value: "other"},
+			},
+			Directive: types.CompletionNoFile,
+		}
+	})
+
+	// 位置参数: 第二个参数只补全 yaml 文件
+	deploy.StringArg("target", "Target", true, "").SetCompletionFunc(func(c types.Command, prefix string) types.CompletionResult {
+		return types.CompletionResult{Candidates: []types.CompletionCandidate{{Value: "blue"}, {Value: "green"}}}
+	})
+	deploy.StringArg("manifest", "Manifest", false, "").SetCompletionFunc(func(c types.Command, prefix string) types.CompletionResult {
+		if c.Arg(0) != "blue" {
+			t.Errorf("completion func should see parsed target, got %q", c.Arg(0))
+		}
+		return types.CompletionResult{Directive: types.CompletionFilterExt, Extensions: []string{".yaml", ".yml"}}
+	})
+
+	complete := func(args ...string) string {
+		var buf strings.Builder
+		root.SetOut(&buf)
+		if err := completion.HandleDynamicComplete(root, types.InstructionAll, args); err != nil {
+			t.Fatalf("HandleDynamicComplete error: %v", err)
+		}
+		return buf.String()
+	}
+
+	out := complete("pr", "--namespace", "deploy", "--env", "prod", "--namespace")
+	for _, want := range []string{"MATCHES:prod-api prod-web\n", "DESC:prod-api\tAPI services\n", "DIRECTIVE:1\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("flag completion output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "DESC:prod-web") {
+		t.Errorf("candidates without description should not output DESC:\n%s", out)
+	}
+
+	out = complete("gr", "deploy", "deploy")
+	if !strings.Contains(out, "MATCHES:green\n") || !strings.Contains(out, "DIRECTIVE:0\n") {
+		t.Errorf("positional completion output unexpected:\n%s", out)
+	}
+
+	out = complete("", "blue", "deploy", "blue")
+	if !strings.Contains(out, "MATCHES:\n") || !strings.Contains(out, "DIRECTIVE:2\n") || !strings.Contains(out, "EXTS:.yaml .yml\n") {
+		t.Errorf("directive output unexpected:\n%s", out)
+	}
+
+	// 没有补全函数时不输出补全指令
+	if out := complete("--", "deploy", "deploy"); strings.Contains(out, "DIRECTIVE:") {
+		t.Errorf("flag name completion should not output DIRECTIVE:\n%s", out)
+	}
+}
+
+// TestCompletionValueHint 测试声明的值类型在静态脚本和 all 指令中生效
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionValueHint(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.String("config", "c", "Config file", "").SetValueHint(types.ValueKindFile, "yaml", ".yml")
+	root.String("dir", "", "Work dir", "").SetValueHint(types.ValueKindDir)
+	root.String("host", "", "Server host", "").SetValueHint(types.ValueKindHostname)
+	root.String("name", "", "Name", "").SetValueHint(types.ValueKindNone)
+	root.StringArg("src", "Source dir", false, "").SetValueHint(types.ValueKindDir)
+
+	statics := map[string][]string{
+		types.BashShell: {
+			`_flag_params["/|--config"]="required|file"`,
+			`_enum_options["/|--config"]=".yaml|.yml"`,
+			`_flag_params["/|--dir"]="required|dir"`,
+			`_flag_params["/|--host"]="required|hostname"`,
+			`_flag_params["/|--name"]="required|none"`,
+		},
+		types.ZshShell: {
+			"'/|--dir' 'required|dir'",
+			"'/|-c' '.yaml\n.yml'",
+		},
+		types.FishShell: {
+			"-l config -x -a '(__fish_complete_suffix .yaml; __fish_complete_suffix .yml)'",
+			"-l dir -x -a '(__fish_complete_directories)'",
+			"-l host -x -a '(__fish_print_hostnames)'",
+			"-l name -x -d 'Name'",
+		},
+		types.PwshShell: {
+			`Parameter = "--config"; ParamType = "required"; ValueType = "file"; Options = @('.yaml', '.yml')`,
+			`Parameter = "--host"; ParamType = "required"; ValueType = "hostname"`,
+		},
+	}
+	for shell, wants := range statics {
+		script, err := completion.GenerateStatic(root, shell)
+		if err != nil {
+			t.Fatalf("GenerateStatic(%s) error: %v", shell, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s script missing %q", shell, want)
+			}
+		}
+	}
+
+	complete := func(args ...string) string {
+		var buf strings.Builder
+		root.SetOut(&buf)
+		if err := completion.HandleDynamicComplete(root, types.InstructionAll, args); err != nil {
+			t.Fatalf("HandleDynamicComplete error: %v", err)
+		}
+		return buf.String()
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"", "--config", "--config"}, []string{"DIRECTIVE:2\n", "EXTS:.yaml .yml\n"}},
+		{[]string{"", "--dir", "--dir"}, []string{"DIRECTIVE:4\n"}},
+		{[]string{"", "--host", "--host"}, []string{"DIRECTIVE:8\n"}},
+		{[]string{"", "--name", "--name"}, []string{"DIRECTIVE:1\n"}},
+		{[]string{"sr", ""}, []string{"MATCHES:\n", "DIRECTIVE:4\n"}},
+	}
+	for _, tt := range tests {
+		out := complete(tt.args...)
+		for _, want := range tt.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("complete %q output missing %q:\n%s", tt.args, want, out)
+			}
+		}
+	}
+
+	// 未声明值类型的标志不输出补全指令
+	if out := complete("--", ""); strings.Contains(out, "DIRECTIVE:") {
+		t.Errorf("flag name completion should not output DIRECTIVE:\n%s", out)
+	}
+}
+
+// TestCompletionJSON 测试 json 指令和 QFLAG_COMPLETE_FORMAT 环境变量输出的 JSON 格式
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionJSON(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.Enum("mode", "m", "Run mode", "fast", []string{"fast", "safe mode"})
+	root.String("out", "o", "Output dir", "").SetValueHint(types.ValueKindDir)
+	root.Enum("level", "l", "Log level", "info", []string{"warn", "info", "debug"})
+	server := cmd.NewCmd("server", "s", types.ContinueOnError)
+	server.SetDesc("Manage the\nserver")
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	complete := func(instruction string, args ...string) (string, types.CompleteResponse) {
+		var buf strings.Builder
+		root.SetOut(&buf)
+		if err := completion.HandleDynamicComplete(root, instruction, args); err != nil {
+			t.Fatalf("HandleDynamicComplete error: %v", err)
+		}
+		var resp types.CompleteResponse
+		if instruction == types.InstructionJSON || os.Getenv(types.CompleteFormatEnv) != "" {
+			if err := json.Unmarshal([]byte(buf.String()), &resp); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+		}
+		return buf.String(), resp
+	}
+
+	// 普通候选项: 带描述和类型, 每个候选项单独一行
+	out, resp := complete(types.InstructionJSON, "se", "")
+	if resp.Version != types.CompleteJSONVersion || resp.Context != "/" || resp.Directive != types.CompletionDefault {
+		t.Errorf("unexpected response header: %+v", resp)
+	}
+	want := types.CompleteItem{Value: "server", Desc: "Manage the server", Kind: types.CandidateKindCommand}
+	if len(resp.Candidates) == 0 || resp.Candidates[0] != want {
+		t.Errorf("Candidates = %+v, want first %+v", resp.Candidates, want)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != len(resp.Candidates)+2 || !strings.HasSuffix(lines[0], `"candidates":[`) || lines[len(lines)-1] != "]}" {
+		t.Errorf("unexpected line layout:\n%s", out)
+	}
+
+	_, resp = complete(types.InstructionJSON, "--mo", "")
+	if len(resp.Candidates) != 1 || resp.Candidates[0] != (types.CompleteItem{Value: "--mode", Desc: "Run mode", Kind: types.CandidateKindFlag}) {
+		t.Errorf("flag candidates = %+v", resp.Candidates)
+	}
+	if resp.Directive != types.CompletionNoFile {
+		t.Errorf("flag name completion directive = %v, want CompletionNoFile", resp.Directive)
+	}
+
+	// 枚举值可以包含空格
+	_, resp = complete(types.InstructionJSON, "sa", "--mode", "--mode")
+	if resp.Flag != "--mode" || len(resp.Candidates) != 1 || resp.Candidates[0].Value != "safe mode" || resp.Candidates[0].Kind != types.CandidateKindValue {
+		t.Errorf("enum response = %+v", resp)
+	}
+
+	// 枚举候选项按声明顺序输出
+	for i := 0; i < 5; i++ {
+		_, resp = complete(types.InstructionJSON, "", "--level", "--level")
+		var values []string
+		for _, item := range resp.Candidates {
+			values = append(values, item.Value)
+		}
+		if !slices.Equal(values, []string{"warn", "info", "debug"}) {
+			t.Fatalf("enum candidates = %v, want [warn info debug]", values)
+		}
+	}
+
+	// 值类型提示
+	_, resp = complete(types.InstructionJSON, "", "--out", "--out")
+	if resp.ValueKind != "dir" || resp.Directive != types.CompletionDirsOnly || len(resp.Candidates) != 0 {
+		t.Errorf("value kind response = %+v", resp)
+	}
+
+	// 未设置环境变量时 all 指令保持原有格式
+	if out, _ := complete(types.InstructionAll, "se", ""); !strings.HasPrefix(out, "CONTEXT:/\n") {
+		t.Errorf("all instruction should keep the line format:\n%s", out)
+	}
+
+	t.Setenv(types.CompleteFormatEnv, types.CompleteFormatJSON)
+	if _, resp := complete(types.InstructionAll, "se", ""); resp.Version != types.CompleteJSONVersion || len(resp.Candidates) == 0 {
+		t.Errorf("all instruction with %s should output JSON: %+v", types.CompleteFormatEnv, resp)
+	}
+}
+
+// TestCompletionContextAbbreviation 测试 context 指令与解析器一致地解析缩写子命令
+//
+// 参数:
+//   - t: 测试实例
+func TestCompletionContextAbbreviation(t *testing.T) {
+	root := cmd.NewCmd("mytool", "", types.ContinueOnError)
+	server := cmd.NewCmd("server", "", types.ContinueOnError)
+	start := cmd.NewCmd("start", "", types.ContinueOnError)
+	status := cmd.NewCmd("status", "", types.ContinueOnError)
+	if err := server.AddSubCmds(start, status); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	tokens := []string{"mytool", "ser", "star"}
+	if got := completion.CalculateContext(root, tokens, len(tokens)).Context; got != "/" {
+		t.Errorf("abbreviation disabled: expected '/', got %q", got)
+	}
+
+	root.SetAllowAbbrev(true)
+	if got := completion.CalculateContext(root, tokens, len(tokens)).Context; got != "/server/start/" {
+		t.Errorf("expected '/server/start/', got %q", got)
+	}
+
+	// 有歧义的前缀停止在父命令
+	tokens = []string{"mytool", "ser", "st"}
+	if got := completion.CalculateContext(root, tokens, len(tokens)).Context; got != "/server/" {
+		t.Errorf("ambiguous prefix: expected '/server/', got %q", got)
+	}
+}
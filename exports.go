@@ -4,8 +4,10 @@ package qflag
 import (
 	"gitee.com/MM-Q/qflag/internal/cmd"
 	"gitee.com/MM-Q/qflag/internal/completion"
+	"gitee.com/MM-Q/qflag/internal/config"
 	"gitee.com/MM-Q/qflag/internal/flag"
 	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
 )
 
 // Command 定义了命令行工具中命令的基本接口, 包括标志管理、参数解析、子命令管理等功能
@@ -24,6 +26,131 @@ type RequiredGroup = types.RequiredGroup
 // 当触发标志被设置时，目标标志会受到约束（互斥或必需）
 type FlagDependency = types.FlagDependency
 
+// ArgSpec 定义了一个命名位置参数, 包括是否必需、是否可变以及值容器
+// 解析时按声明顺序绑定位置参数, 参数个数或类型不符时解析器会返回错误
+type ArgSpec = types.ArgSpec
+
+// ConfigDecoder 配置文件解码器接口
+// 将配置文件内容解码为嵌套映射, 顶层键对应标志长名称, 嵌套映射对应子命令分区。
+// 实现此接口即可接入 YAML 等其他格式, 而无需 qflag 依赖第三方库。
+type ConfigDecoder = types.ConfigDecoder
+
+// JSONDecoder JSON 格式的配置文件解码器, 默认用于 .json 文件
+type JSONDecoder = config.JSONDecoder
+
+// INIDecoder 类 TOML/INI 格式的配置文件解码器, 默认用于 .toml/.ini/.conf/.cfg 文件
+// 支持 key = value、[server.start] 分区、注释、引号字符串和单行数组
+type INIDecoder = config.INIDecoder
+
+// ValueSource 标志值的来源 (默认值、命令行参数、环境变量、配置文件或程序设置)
+type ValueSource = types.ValueSource
+
+// 标志值来源常量
+const (
+	SourceDefault = types.SourceDefault // 默认值, 标志未被设置
+	SourceCLI     = types.SourceCLI     // 命令行参数
+	SourceEnv     = types.SourceEnv     // 环境变量
+	SourceConfig  = types.SourceConfig  // 配置文件
+	SourceProgram = types.SourceProgram // 程序中直接设置
+)
+
+// ValueOrigin 标志值的来源信息, 包括来源、原始字符串和来源位置
+// (标志写法、环境变量名或 "配置文件:键路径")
+type ValueOrigin = types.ValueOrigin
+
+// FlagOrigin 命令中一个标志及其值来源, 由 Cmd.FlagOrigins 返回
+type FlagOrigin = types.FlagOrigin
+
+// ParseErrors 错误收集模式下汇总的解析错误
+// 通过 Errors 字段遍历每个问题, errors.As / errors.Is 可以匹配其中任意一个错误
+type ParseErrors = types.ParseErrors
+
+// 结构化解析错误, 调用方可以通过 errors.As 取出命令路径、标志名、错误值等信息
+type (
+	// UnknownSubcommandError 未知子命令错误
+	UnknownSubcommandError = types.UnknownSubcommandError
+
+	// UnknownFlagError 未知标志错误
+	UnknownFlagError = types.UnknownFlagError
+
+	// AmbiguousSubcommandError 子命令缩写歧义错误
+	AmbiguousSubcommandError = types.AmbiguousSubcommandError
+
+	// AmbiguousFlagError 标志缩写歧义错误
+	AmbiguousFlagError = types.AmbiguousFlagError
+
+	// InvalidValueError 标志值无效错误 (命令行参数、环境变量或配置文件)
+	InvalidValueError = types.InvalidValueError
+
+	// MissingValueError 标志缺少值错误
+	MissingValueError = types.MissingValueError
+
+	// UnexpectedValueError 标志不接受值错误
+	UnexpectedValueError = types.UnexpectedValueError
+
+	// MutexGroupError 互斥组违规错误
+	MutexGroupError = types.MutexGroupError
+
+	// RequiredGroupError 必需组违规错误
+	RequiredGroupError = types.RequiredGroupError
+
+	// DependencyError 标志依赖关系违规错误
+	DependencyError = types.DependencyError
+
+	// MissingArgumentError 缺少必需位置参数错误
+	MissingArgumentError = types.MissingArgumentError
+
+	// InvalidArgumentError 位置参数值无效错误
+	InvalidArgumentError = types.InvalidArgumentError
+
+	// TooManyArgumentsError 位置参数过多错误
+	TooManyArgumentsError = types.TooManyArgumentsError
+
+	// UnknownConfigKeyError 未知配置键错误
+	UnknownConfigKeyError = types.UnknownConfigKeyError
+
+	// NoRunFuncError 命令没有设置运行函数错误
+	NoRunFuncError = types.NoRunFuncError
+)
+
+var (
+	// ErrHelp 用户请求了帮助信息 (如 --help)
+	ErrHelp = types.ErrHelp
+
+	// ErrVersion 用户请求了版本信息 (如 --version)
+	ErrVersion = types.ErrVersion
+
+	// ErrCompletion 用户请求了生成或安装补全脚本 (如 --completion)
+	ErrCompletion = types.ErrCompletion
+)
+
+// SignalError 收到终止信号错误, 是 SignalContext 创建的上下文的取消原因
+type SignalError = types.SignalError
+
+// ExitCoder 携带退出码的错误, 运行函数返回此类错误时 Execute 使用错误自身的退出码
+type ExitCoder = types.ExitCoder
+
+// LocalizedError 支持中文错误信息的错误, 解析器返回的错误类型都实现了此接口
+type LocalizedError = types.LocalizedError
+
+// 惯用的进程退出码
+const (
+	// ExitCodeOK 执行成功, 或用户请求了帮助、版本、补全
+	ExitCodeOK = types.ExitCodeOK
+
+	// ExitCodeFailure 运行函数或钩子执行失败
+	ExitCodeFailure = types.ExitCodeFailure
+
+	// ExitCodeUsage 命令调用方式错误, 也是值验证错误的默认退出码
+	ExitCodeUsage = types.ExitCodeUsage
+
+	// ExitCodeTimeout 执行超时 (上下文超过截止时间)
+	ExitCodeTimeout = types.ExitCodeTimeout
+
+	// ExitCodeInterrupted 执行被中断 (收到 SIGINT 或上下文被取消)
+	ExitCodeInterrupted = types.ExitCodeInterrupted
+)
+
 // DepType 依赖关系类型
 type DepType = types.DepType
 
@@ -36,6 +163,30 @@ const (
 	DepRequired = types.DepRequired
 )
 
+// HookType 生命周期钩子类型
+type HookType = types.HookType
+
+// 生命周期钩子类型常量
+const (
+	// PersistentPreRunHook 持久前置钩子, 在所有子孙命令 (包括自身) 运行前执行
+	PersistentPreRunHook = types.PersistentPreRunHook
+
+	// PreRunHook 前置钩子, 仅在自身运行前执行
+	PreRunHook = types.PreRunHook
+
+	// PostRunHook 后置钩子, 仅在自身运行后执行
+	PostRunHook = types.PostRunHook
+
+	// PersistentPostRunHook 持久后置钩子, 在所有子孙命令 (包括自身) 运行后执行
+	PersistentPostRunHook = types.PersistentPostRunHook
+)
+
+// RunFunc 命令运行函数
+type RunFunc = types.RunFunc
+
+// Middleware 运行函数中间件, 包装命令的运行函数
+type Middleware = types.Middleware
+
 // CmdConfig 包含了命令的各种配置选项, 用于自定义命令的行为和外观
 // 这些配置会影响命令的帮助信息显示、环境变量处理、错误提示等
 type CmdConfig = types.CmdConfig
@@ -116,6 +267,9 @@ const (
 	FlagTypeStringSlice FlagType = types.FlagTypeStringSlice // 字符串切片标志, 字符串数组
 	FlagTypeIntSlice    FlagType = types.FlagTypeIntSlice    // 整数切片标志, 整数数组
 	FlagTypeInt64Slice  FlagType = types.FlagTypeInt64Slice  // 64位整数切片标志, 64位整数数组
+
+	// 计数类型
+	FlagTypeCount FlagType = types.FlagTypeCount // 计数标志, 每出现一次加一
 )
 
 // Validator 验证器函数类型
@@ -139,6 +293,7 @@ const (
 // 空值处理:
 //   - StringFlag: 空字符串不经过验证器，直接设置
 //   - BoolFlag: 不经过验证器（无空值概念）
+//   - CountFlag: 空字符串表示计数加一, 验证器作用于加一后的值
 //   - 集合类型 (MapFlag, StringSliceFlag, IntSliceFlag, Int64SliceFlag): 空字符串不经过验证器，创建空集合
 //   - 其他类型: 空字符串直接返回错误，不经过验证器
 //
@@ -273,11 +428,69 @@ type CmdOpts = cmd.CmdOpts
 //   - 初始化 map 和 slice 避免空指针
 var NewCmdOpts = cmd.NewCmdOpts
 
+// CompletionFunc 动态补全函数, 通过标志或位置参数值容器的 SetCompletionFunc 设置
+type CompletionFunc = types.CompletionFunc
+
+// CompletionResult 补全函数的返回结果, 包括候选项和补全指令
+type CompletionResult = types.CompletionResult
+
+// CompletionCandidate 补全候选项, 可以带有描述
+type CompletionCandidate = types.CompletionCandidate
+
+// CompletionDirective 补全指令, 决定补全函数没有返回候选项时的处理方式
+type CompletionDirective = types.CompletionDirective
+
+const (
+	// CompletionDefault 没有候选项时回退到文件补全
+	CompletionDefault = types.CompletionDefault
+
+	// CompletionNoFile 没有候选项时不补全文件
+	CompletionNoFile = types.CompletionNoFile
+
+	// CompletionFilterExt 没有候选项时只补全目录和指定扩展名的文件
+	CompletionFilterExt = types.CompletionFilterExt
+
+	// CompletionDirsOnly 没有候选项时只补全目录
+	CompletionDirsOnly = types.CompletionDirsOnly
+
+	// CompletionHostnames 没有候选项时补全主机名
+	CompletionHostnames = types.CompletionHostnames
+)
+
+// CompleteResponse __complete json 指令输出的 JSON 结构, 供外部工具解析补全结果
+type CompleteResponse = types.CompleteResponse
+
+// CompleteItem CompleteResponse 中的候选项
+type CompleteItem = types.CompleteItem
+
+// ValueKind 值类型, 通过标志或位置参数值容器的 SetValueHint 声明
+type ValueKind = types.ValueKind
+
+// ValueHint 值类型提示, 包括值类型和允许的扩展名
+type ValueHint = types.ValueHint
+
+const (
+	// ValueKindAuto 未声明值类型
+	ValueKindAuto = types.ValueKindAuto
+
+	// ValueKindFile 文件路径, 可以限定扩展名
+	ValueKindFile = types.ValueKindFile
+
+	// ValueKindDir 目录路径
+	ValueKindDir = types.ValueKindDir
+
+	// ValueKindHostname 主机名
+	ValueKindHostname = types.ValueKindHostname
+
+	// ValueKindNone 任意文本, 不提供补全
+	ValueKindNone = types.ValueKindNone
+)
+
 // GenerateCompletion 生成补全脚本
 //
 // 参数:
 //   - cmd: 要生成补全脚本的命令
-//   - shellType: Shell类型 (bash, pwsh, powershell)
+//   - shellType: Shell类型 (bash, zsh, fish, pwsh, powershell)
 //
 // 返回值:
 //   - string: 生成的补全脚本
@@ -293,7 +506,7 @@ var GenerateCompletion = completion.Generate
 //
 // 参数:
 //   - cmd: 要生成补全脚本的命令
-//   - shellType: Shell类型 (bash, pwsh, powershell)
+//   - shellType: Shell类型 (bash, zsh, fish, pwsh, powershell)
 //
 // 功能说明:
 //   - 生成自动补全脚本
@@ -301,6 +514,61 @@ var GenerateCompletion = completion.Generate
 //   - 便于在命令行中直接使用
 var GenAndPrintCompletion = completion.GenAndPrint
 
+// RegisterConfigDecoder 按扩展名注册配置文件解码器
+//
+// 参数:
+//   - ext: 文件扩展名, 如 ".yaml" 或 "yaml"
+//   - decoder: 解码器, 为nil时删除该扩展名的解码器
+//
+// 功能说明:
+//   - 命令未设置解码器时, 按配置文件扩展名选择已注册的解码器
+//   - 扩展名不区分大小写, 重复注册会覆盖之前的解码器
+var RegisterConfigDecoder = config.RegisterDecoder
+
+// SignalContext 创建一个在收到 SIGINT/SIGTERM 时取消的上下文
+//
+// 参数:
+//   - parent: 父上下文
+//
+// 返回值:
+//   - context.Context: 收到信号或父上下文结束时取消的上下文
+//   - context.CancelFunc: 停止监听信号并取消上下文, 使用完毕后必须调用
+//
+// 功能说明:
+//   - 配合 ParseAndRouteContext 使用, 运行函数通过 cmd.Context() 感知 Ctrl-C
+//   - 收到信号时以 *SignalError 作为取消原因
+var SignalContext = utils.SignalContext
+
+// ContextExitCode 将上下文的取消原因映射为惯用的退出码
+//
+// 参数:
+//   - ctx: 执行时使用的上下文
+//
+// 返回值:
+//   - int: 退出码, 收到信号时为 128 + 信号编号, 超时为 124, 其他取消为 130
+//   - bool: 上下文已结束时返回true
+var ContextExitCode = utils.ContextExitCode
+
+// ExitCodeOf 将解析或执行返回的错误映射为进程退出码
+//
+// 参数:
+//   - err: ParseAndRoute 等方法返回的错误
+//   - validationCode: 值验证错误使用的退出码
+//
+// 返回值:
+//   - int: 退出码, 调用方式错误为 2, 值验证错误为 validationCode,
+//     ExitCoder 为错误自身的退出码, 其他错误为 1
+var ExitCodeOf = types.ExitCodeOf
+
+// LocalizedMessage 按语言设置获取错误信息, 开启中文且错误实现了 LocalizedError 时返回中文信息
+var LocalizedMessage = types.LocalizedMessage
+
+// IsUsageError 检查错误是否为命令调用方式错误 (未知标志或子命令、缺少参数等)
+var IsUsageError = types.IsUsageError
+
+// IsValidationError 检查错误是否为值验证错误 (无效值、违反互斥组/必需组/依赖关系规则)
+var IsValidationError = types.IsValidationError
+
 // StringFlag 字符串标志
 // StringFlag 用于处理字符串类型的命令行参数。
 // 它接受任何字符串值, 包括空字符串。
@@ -311,6 +579,14 @@ type StringFlag = flag.StringFlag
 // 它接受多种布尔值表示形式, 包括 "true", "false", "1", "0", "t", "f", "TRUE", "FALSE" 等。
 type BoolFlag = flag.BoolFlag
 
+// CountFlag 计数标志
+// CountFlag 用于处理 -v -v -v 或 -vvv 这类重复出现的开关, 每出现一次计数加一。
+//
+// 注意事项:
+//   - 支持 --verbose=3 或 -v=3 显式设置计数
+//   - 可以使用 validators.IntRange 等整数验证器限制范围
+type CountFlag = flag.CountFlag
+
 // IntFlag 整数标志
 // IntFlag 用于处理整数类型的命令行参数。
 // 使用平台相关的int类型, 在32位系统上为32位整数, 在64位系统上为64位整数。
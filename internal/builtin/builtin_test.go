@@ -1,6 +1,10 @@
 package builtin
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"gitee.com/MM-Q/qflag/internal/flag"
@@ -138,14 +142,14 @@ func TestBuiltinFlagManager_HandleBuiltinFlags(t *testing.T) {
 	helper := mock.NewTestHelper()
 
 	tests := []struct {
-		name        string
-		cmd         *mock.MockCommand
-		expectPanic bool
+		name    string
+		cmd     *mock.MockCommand
+		wantErr error
 	}{
 		{
-			name:        "没有设置内置标志",
-			cmd:         helper.CreateMockCommandWithFlags("test", "t", "Test command"),
-			expectPanic: false,
+			name:    "没有设置内置标志",
+			cmd:     helper.CreateMockCommandWithFlags("test", "t", "Test command"),
+			wantErr: nil,
 		},
 		{
 			name: "设置了帮助标志",
@@ -159,7 +163,7 @@ func TestBuiltinFlagManager_HandleBuiltinFlags(t *testing.T) {
 				_ = helpFlag.Set("true")
 				return cmd
 			}(),
-			expectPanic: true, // 因为Handle方法会调用os.Exit(0)
+			wantErr: types.ErrHelp,
 		},
 		{
 			name: "设置了版本标志",
@@ -174,29 +178,17 @@ func TestBuiltinFlagManager_HandleBuiltinFlags(t *testing.T) {
 				_ = versionFlag.Set("true")
 				return cmd
 			}(),
-			expectPanic: true, // 因为Handle方法会调用os.Exit(0)
+			wantErr: types.ErrVersion,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			defer func() {
-				if r := recover(); r != nil {
-					if !tt.expectPanic {
-						t.Errorf("HandleBuiltinFlags() unexpected panic: %v", r)
-					}
-				} else {
-					if tt.expectPanic {
-						t.Error("HandleBuiltinFlags() expected panic but did not panic")
-					}
-				}
-			}()
-
 			manager := NewBuiltinFlagManager()
 			err := manager.HandleBuiltinFlags(tt.cmd)
 
-			if err != nil && !tt.expectPanic {
-				t.Errorf("HandleBuiltinFlags() error = %v", err)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("HandleBuiltinFlags() error = %v, want %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -463,3 +455,92 @@ func TestGetShellTypeFromArgs(t *testing.T) {
 		})
 	}
 }
+
+// TestInstallCompletionHandler_Zsh 测试 zsh 补全的安装位置和加载命令
+func TestInstallCompletionHandler_Zsh(t *testing.T) {
+	handler := &InstallCompletionHandler{}
+	home := t.TempDir()
+
+	// 配置文件优先使用 $ZDOTDIR
+	t.Setenv("ZDOTDIR", "")
+	if got, want := handler.getProfilePath(home, types.ZshShell), filepath.Join(home, ".zshrc"); got != want {
+		t.Errorf("getProfilePath() = %q, want %q", got, want)
+	}
+	zdotdir := filepath.Join(home, "zdot")
+	t.Setenv("ZDOTDIR", zdotdir)
+	if got, want := handler.getProfilePath(home, types.ZshShell), filepath.Join(zdotdir, ".zshrc"); got != want {
+		t.Errorf("getProfilePath() = %q, want %q", got, want)
+	}
+
+	// 加载命令把脚本目录加入 fpath 并注册补全函数
+	dir := filepath.Join(home, types.CompletionsDirName, types.ZshCompletionsDirName)
+	scriptPath := filepath.Join(dir, "_myapp")
+	cmd := handler.generateLoadCommand(scriptPath, types.ZshShell, "myapp")
+	for _, want := range []string{"fpath=('" + dir + "' $fpath)", "compinit", "autoload -Uz _myapp && compdef _myapp "} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("load command %q missing %q", cmd, want)
+		}
+	}
+
+	// 重复安装不会重复添加加载命令
+	profile := filepath.Join(home, ".zshrc")
+	for i := 0; i < 2; i++ {
+		if err := handler.addLoadCommandToProfile(profile, scriptPath, types.ZshShell, "myapp"); err != nil {
+			t.Fatalf("addLoadCommandToProfile() error = %v", err)
+		}
+	}
+	content, err := os.ReadFile(profile)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if n := strings.Count(string(content), "fpath=("); n != 1 {
+		t.Errorf("load command added %d times, want 1:\n%s", n, content)
+	}
+}
+
+// TestInstallCompletionHandler_Fish 测试 fish 补全脚本安装到自动加载目录且不修改配置文件
+func TestInstallCompletionHandler_Fish(t *testing.T) {
+	handler := &InstallCompletionHandler{}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// 补全目录优先使用 $XDG_CONFIG_HOME
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if got, want := handler.getFishCompletionsDir(home), filepath.Join(home, ".config", "fish", "completions"); got != want {
+		t.Errorf("getFishCompletionsDir() = %q, want %q", got, want)
+	}
+	configDir := filepath.Join(home, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	dir := filepath.Join(configDir, "fish", "completions")
+	if got := handler.getFishCompletionsDir(home); got != dir {
+		t.Errorf("getFishCompletionsDir() = %q, want %q", got, dir)
+	}
+
+	helper := mock.NewTestHelper()
+	cmd := helper.CreateMockCommandWithFlags("test", "t", "Test command")
+	installFlag := flag.NewEnumFlag(types.InstallCompletionFlagName, "", "Install", types.FishShell, types.SupportedShells)
+	if err := cmd.AddFlag(installFlag); err != nil {
+		t.Fatalf("AddFlag() error = %v", err)
+	}
+
+	if err := handler.Handle(cmd); err != types.ErrCompletion {
+		t.Fatalf("Handle() error = %v, want ErrCompletion", err)
+	}
+
+	base := filepath.Base(os.Args[0])
+	scriptPath := filepath.Join(dir, strings.TrimSuffix(base, filepath.Ext(base))+".fish")
+	content, err := os.ReadFile(scriptPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(content), "complete -c ") {
+		t.Errorf("fish script should contain complete commands:\n%s", content)
+	}
+
+	// 不创建任何配置文件
+	for _, name := range []string{".bashrc", ".bash_profile", ".zshrc"} {
+		if _, err := os.Stat(filepath.Join(home, name)); !os.IsNotExist(err) {
+			t.Errorf("fish install should not touch %s", name)
+		}
+	}
+}
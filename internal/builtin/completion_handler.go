@@ -1,8 +1,6 @@
 package builtin
 
 import (
-	"os"
-
 	"gitee.com/MM-Q/qflag/internal/completion"
 	"gitee.com/MM-Q/qflag/internal/types"
 )
@@ -19,20 +17,19 @@ type CompletionHandler struct{}
 //   - cmd: 要处理的命令
 //
 // 返回值:
-//   - error: 处理失败时返回错误
+//   - error: 输出脚本后返回 types.ErrCompletion
 //
 // 功能说明:
 //   - 从命令行参数获取Shell类型
 //   - 生成对应的补全脚本
-//   - 输出脚本并退出程序
+//   - 输出脚本并返回 types.ErrCompletion, 由调用方决定是否退出程序
 func (h *CompletionHandler) Handle(cmd types.Command) error {
 	// 获取shell类型参数
 	shellType := getShellTypeFromArgs(cmd)
 
 	// 生成补全脚本
 	completion.GenAndPrint(cmd, shellType)
-	os.Exit(0)
-	return nil
+	return types.ErrCompletion
 }
 
 // Type 返回标志类型
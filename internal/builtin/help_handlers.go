@@ -1,15 +1,13 @@
 package builtin
 
 import (
-	"os"
-
 	"gitee.com/MM-Q/qflag/internal/types"
 )
 
 // HelpHandler 帮助标志处理器
 //
 // HelpHandler 负责处理帮助标志 (-h/--help) 。
-// 当用户指定帮助标志时, 会打印命令的帮助信息并退出程序。
+// 当用户指定帮助标志时, 会打印命令的帮助信息并返回 types.ErrHelp。
 type HelpHandler struct{}
 
 // Handle 处理帮助标志
@@ -18,15 +16,14 @@ type HelpHandler struct{}
 //   - cmd: 要处理的命令
 //
 // 返回值:
-//   - error: 处理失败时返回错误
+//   - error: 总是返回 types.ErrHelp
 //
 // 功能说明:
 //   - 打印命令的帮助信息
-//   - 使用状态码0退出程序
+//   - 返回 types.ErrHelp, 由调用方决定是否退出程序
 func (h *HelpHandler) Handle(cmd types.Command) error {
 	cmd.PrintHelp()
-	os.Exit(0)
-	return nil
+	return types.ErrHelp
 }
 
 // Type 返回标志类型
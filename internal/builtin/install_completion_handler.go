@@ -14,9 +14,12 @@ import (
 // InstallCompletionHandler 安装补全标志处理器
 //
 // 负责处理 --install-completion 标志，自动完成：
-// 1. 创建 ~/.qflag_completions/ 目录
-// 2. 生成补全脚本到该目录
-// 3. 将加载命令添加到 Shell 配置文件
+// 1. 创建 ~/.qflag_completions/ 目录 (zsh 为 ~/.qflag_completions/zsh/)
+// 2. 生成补全脚本到该目录 (zsh 脚本以补全函数名命名, 如 _myapp)
+// 3. 将加载命令添加到 Shell 配置文件 (zsh 为 fpath 设置和 compdef 注册)
+//
+// fish 的补全脚本直接写入 fish 自动加载的 ~/.config/fish/completions/<程序名>.fish,
+// 不修改任何配置文件。
 type InstallCompletionHandler struct{}
 
 // Handle 处理安装补全标志
@@ -25,14 +28,14 @@ type InstallCompletionHandler struct{}
 //   - cmd: 要处理的命令
 //
 // 返回值:
-//   - error: 处理失败时返回错误
+//   - error: 处理失败时返回错误, 安装成功时返回 types.ErrCompletion
 //
 // 功能说明:
 //   - 获取用户家目录
 //   - 创建补全脚本存放目录
 //   - 生成补全脚本到文件
 //   - 添加加载命令到 Shell 配置文件
-//   - 输出成功信息并退出程序
+//   - 输出成功信息并返回 types.ErrCompletion, 由调用方决定是否退出程序
 func (h *InstallCompletionHandler) Handle(cmd types.Command) error {
 	shellType := h.getShellTypeFromArgs(cmd)
 	// 获取程序名并去掉可执行文件扩展名（如 .exe），将特殊字符替换为下划线
@@ -44,18 +47,32 @@ func (h *InstallCompletionHandler) Handle(cmd types.Command) error {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	// 2. 创建补全目录
+	// 2. 创建补全目录, zsh 使用单独的目录以便加入 fpath, fish 使用其自动加载目录
 	completionsDir := filepath.Join(homeDir, types.CompletionsDirName)
+	switch shellType {
+	case types.ZshShell:
+		completionsDir = filepath.Join(completionsDir, types.ZshCompletionsDirName)
+	case types.FishShell:
+		completionsDir = h.getFishCompletionsDir(homeDir)
+	}
 	if err := os.MkdirAll(completionsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create completions directory: %w", err)
 	}
 
 	// 3. 确定脚本文件名和路径
-	scriptName := programName
-	if shellType == types.PwshShell || shellType == types.PowershellShell {
-		scriptName += types.PwshCompletionScriptExt
-	} else {
-		scriptName += types.BashCompletionScriptExt
+	var scriptName string
+	switch shellType {
+	case types.PwshShell, types.PowershellShell:
+		scriptName = programName + types.PwshCompletionScriptExt
+	case types.ZshShell:
+		// fpath 中的脚本文件名必须与补全函数名一致
+		scriptName = completion.ZshFuncName(filepath.Base(os.Args[0]))
+	case types.FishShell:
+		// fish 按命令名自动加载补全脚本, 不能替换特殊字符
+		base := filepath.Base(os.Args[0])
+		scriptName = strings.TrimSuffix(base, filepath.Ext(base)) + types.FishCompletionScriptExt
+	default:
+		scriptName = programName + types.BashCompletionScriptExt
 	}
 	scriptPath := filepath.Join(completionsDir, scriptName)
 
@@ -69,17 +86,19 @@ func (h *InstallCompletionHandler) Handle(cmd types.Command) error {
 		return fmt.Errorf("failed to write completion script: %w", err)
 	}
 
-	// 5. 添加加载命令到配置文件
-	profilePath := h.getProfilePath(homeDir, shellType)
-	if err := h.addLoadCommandToProfile(profilePath, scriptPath, shellType, programName); err != nil {
-		return fmt.Errorf("failed to add load command to profile: %w", err)
+	// 5. 添加加载命令到配置文件 (fish 自动加载, 无需配置文件)
+	var profilePath string
+	if shellType != types.FishShell {
+		profilePath = h.getProfilePath(homeDir, shellType)
+		if err := h.addLoadCommandToProfile(profilePath, scriptPath, shellType, programName); err != nil {
+			return fmt.Errorf("failed to add load command to profile: %w", err)
+		}
 	}
 
-	// 6. 输出成功信息并退出程序
+	// 6. 输出成功信息, 返回哨兵错误表示请求已处理
 	h.printSuccessMessages(scriptPath, profilePath, shellType, cmd)
 
-	os.Exit(0)
-	return nil
+	return types.ErrCompletion
 }
 
 // addLoadCommandToProfile 添加加载命令到配置文件
@@ -101,8 +120,8 @@ func (h *InstallCompletionHandler) addLoadCommandToProfile(profilePath, scriptPa
 		return err
 	}
 
-	// 检查是否已存在
-	if err == nil && strings.Contains(string(content), scriptPath) {
+	// 检查是否已存在 (zsh 的加载命令只包含脚本目录)
+	if err == nil && (strings.Contains(string(content), scriptPath) || strings.Contains(string(content), loadCommand)) {
 		// 已存在，不需要重复添加
 		return nil
 	}
@@ -126,6 +145,21 @@ func (h *InstallCompletionHandler) addLoadCommandToProfile(profilePath, scriptPa
 	return nil
 }
 
+// getFishCompletionsDir 获取 fish 自动加载补全脚本的目录
+//
+// 参数:
+//   - homeDir: 用户家目录
+//
+// 返回值:
+//   - string: 补全目录, 优先使用 $XDG_CONFIG_HOME
+func (h *InstallCompletionHandler) getFishCompletionsDir(homeDir string) string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(homeDir, types.FishConfigDirName)
+	}
+	return filepath.Join(configDir, filepath.FromSlash(types.FishCompletionsDirName))
+}
+
 // getProfilePath 获取配置文件路径
 //
 // 参数:
@@ -142,6 +176,12 @@ func (h *InstallCompletionHandler) getProfilePath(homeDir, shellType string) str
 			return filepath.Join(homeDir, types.PwshProfileDirWindows, types.PwshProfileFileName)
 		}
 		return filepath.Join(homeDir, types.PwshProfileDirUnix, types.PwshProfileFileName)
+	case types.ZshShell:
+		// Zsh 配置文件, 优先使用 $ZDOTDIR
+		if dir := os.Getenv("ZDOTDIR"); dir != "" {
+			return filepath.Join(dir, types.ZshProfileFileName)
+		}
+		return filepath.Join(homeDir, types.ZshProfileFileName)
 	default:
 		// Bash 配置文件
 		if runtime.GOOS == "darwin" {
@@ -165,6 +205,10 @@ func (h *InstallCompletionHandler) generateLoadCommand(scriptPath, shellType, pr
 	case types.PwshShell, types.PowershellShell:
 		// PowerShell: 使用程序名生成唯一变量名，避免多个程序冲突
 		return fmt.Sprintf(types.PwshLoadCommandTemplate, programName, scriptPath, programName, programName)
+	case types.ZshShell:
+		// Zsh: 脚本目录加入 fpath, 按补全函数名自动加载
+		funcName := filepath.Base(scriptPath)
+		return fmt.Sprintf(types.ZshLoadCommandTemplate, filepath.Dir(scriptPath), funcName, funcName, filepath.Base(os.Args[0]))
 	default:
 		// Bash: 使用 -f 检查文件存在
 		return fmt.Sprintf(types.BashLoadCommandTemplate, scriptPath, scriptPath)
@@ -231,7 +275,7 @@ func (h *InstallCompletionHandler) ShouldSkipRegistration(cmd types.Command) boo
 //
 // 参数:
 //   - scriptPath: 补全脚本路径
-//   - profilePath: 配置文件路径
+//   - profilePath: 配置文件路径, 为空表示未修改配置文件 (fish)
 //   - shellType: Shell 类型
 //   - cmd: 命令实例（用于获取语言配置）
 //
@@ -239,23 +283,39 @@ func (h *InstallCompletionHandler) ShouldSkipRegistration(cmd types.Command) boo
 //   - 根据命令配置的语言选择中文或英文输出
 //   - 根据 Shell 类型选择正确的执行命令（source 或 .）
 //   - 使用预定义的常量格式化输出信息
+//   - 输出到命令的标准输出
 func (h *InstallCompletionHandler) printSuccessMessages(scriptPath, profilePath, shellType string, cmd types.Command) {
+	w := cmd.Out()
+
+	// fish 自动加载补全脚本, 当前会话可直接 source 脚本
+	if shellType == types.FishShell {
+		if cmd.Config().UseChinese {
+			fmt.Fprintf(w, types.InstallSuccessScriptPathCN+"\n", scriptPath)
+			fmt.Fprintln(w, types.InstallSuccessFishHintCN)
+		} else {
+			fmt.Fprintf(w, types.InstallSuccessScriptPathEN+"\n", scriptPath)
+			fmt.Fprintln(w, types.InstallSuccessFishHintEN)
+		}
+		fmt.Fprintf(w, types.InstallSuccessBashCmd+"\n", scriptPath)
+		return
+	}
+
 	// 根据语言配置选择输出内容
 	if cmd.Config().UseChinese {
-		fmt.Printf(types.InstallSuccessScriptPathCN+"\n", scriptPath)
-		fmt.Printf(types.InstallSuccessProfilePathCN+"\n", profilePath)
-		fmt.Println(types.InstallSuccessHintCN)
+		fmt.Fprintf(w, types.InstallSuccessScriptPathCN+"\n", scriptPath)
+		fmt.Fprintf(w, types.InstallSuccessProfilePathCN+"\n", profilePath)
+		fmt.Fprintln(w, types.InstallSuccessHintCN)
 	} else {
-		fmt.Printf(types.InstallSuccessScriptPathEN+"\n", scriptPath)
-		fmt.Printf(types.InstallSuccessProfilePathEN+"\n", profilePath)
-		fmt.Println(types.InstallSuccessHintEN)
+		fmt.Fprintf(w, types.InstallSuccessScriptPathEN+"\n", scriptPath)
+		fmt.Fprintf(w, types.InstallSuccessProfilePathEN+"\n", profilePath)
+		fmt.Fprintln(w, types.InstallSuccessHintEN)
 	}
 
 	// 根据 Shell 类型选择执行命令（不区分中英文）
 	if shellType == types.PwshShell || shellType == types.PowershellShell {
-		fmt.Printf(types.InstallSuccessPwshCmd+"\n", profilePath)
+		fmt.Fprintf(w, types.InstallSuccessPwshCmd+"\n", profilePath)
 	} else {
-		fmt.Printf(types.InstallSuccessBashCmd+"\n", profilePath)
+		fmt.Fprintf(w, types.InstallSuccessBashCmd+"\n", profilePath)
 	}
 }
 
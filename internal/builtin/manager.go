@@ -160,7 +160,7 @@ func (m *BuiltinFlagManager) RegisterBuiltinFlags(cmd types.Command) error {
 //   - cmd: 要处理标志的命令
 //
 // 返回值:
-//   - error: 处理失败时返回错误
+//   - error: 处理失败时返回错误, 处理了内置标志时返回对应的哨兵错误 (如 types.ErrHelp)
 //
 // 功能说明:
 //   - 遍历命令的所有标志, 检查是否是内置标志
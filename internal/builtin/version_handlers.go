@@ -2,7 +2,6 @@ package builtin
 
 import (
 	"fmt"
-	"os"
 
 	"gitee.com/MM-Q/qflag/internal/types"
 )
@@ -10,7 +9,7 @@ import (
 // VersionHandler 版本标志处理器
 //
 // VersionHandler 负责处理版本标志 (-v/--version) 。
-// 当用户指定版本标志时, 会打印命令的版本信息并退出程序。
+// 当用户指定版本标志时, 会打印命令的版本信息并返回 types.ErrVersion。
 type VersionHandler struct{}
 
 // Handle 处理版本标志
@@ -19,15 +18,14 @@ type VersionHandler struct{}
 //   - cmd: 要处理的命令
 //
 // 返回值:
-//   - error: 处理失败时返回错误
+//   - error: 总是返回 types.ErrVersion
 //
 // 功能说明:
-//   - 打印命令的版本信息
-//   - 使用状态码0退出程序
+//   - 打印命令的版本信息到命令的标准输出
+//   - 返回 types.ErrVersion, 由调用方决定是否退出程序
 func (h *VersionHandler) Handle(cmd types.Command) error {
-	fmt.Println(cmd.Config().Version)
-	os.Exit(0)
-	return nil
+	fmt.Fprintln(cmd.Out(), cmd.Config().Version)
+	return types.ErrVersion
 }
 
 // Type 返回标志类型
@@ -0,0 +1,327 @@
+// Package cmd 提供命令实现和命令管理功能
+//
+// args.go 包含位置参数声明相关的功能实现
+//
+// 本文件提供了以下主要功能:
+//   - 位置参数声明: 必需/可选参数、可变参数尾部
+//   - 类型化位置参数: 复用标志类型的解析器和验证器
+//
+// 主要方法列表:
+//   - AddArg: 添加位置参数声明
+//   - ArgSpecs: 获取所有位置参数声明
+//   - StringArg/IntArg/...: 创建类型化位置参数
+//   - StringSliceArg/IntSliceArg/Int64SliceArg: 创建可变位置参数
+//
+// 声明规则:
+//   - 参数名称在命令中唯一
+//   - 必需参数不能出现在可选参数之后
+//   - 可变参数只能是最后一个, 且值必须是切片类型
+//
+// 线程安全:
+//   - 所有公共方法都使用读写锁保护
+//   - 支持并发安全的访问和修改
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"gitee.com/MM-Q/qflag/internal/flag"
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// AddArg 添加位置参数声明
+//
+// 参数:
+//   - spec: 位置参数声明
+//
+// 返回值:
+//   - error: 声明无效时返回错误
+//
+// 功能说明:
+//   - 按添加顺序绑定位置参数
+//   - 解析时检查参数个数, 并使用值容器解析和验证参数值
+//   - 支持并发安全的添加操作
+//
+// 错误情况:
+//   - 参数名称为空或重复
+//   - 值容器为nil
+//   - 在可变参数之后继续添加参数
+//   - 必需参数出现在可选参数之后
+//   - 可变参数的值容器不是切片类型
+func (c *Cmd) AddArg(spec types.ArgSpec) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if spec.Name == "" {
+		return fmt.Errorf("empty argument name in '%s'", c.Name())
+	}
+	if spec.Value == nil {
+		return fmt.Errorf("nil value for argument '%s' in '%s'", spec.Name, c.Name())
+	}
+	if spec.Variadic {
+		if _, ok := spec.Value.(types.ItemSetter); !ok {
+			return fmt.Errorf("variadic argument '%s' requires a slice value in '%s'", spec.Name, c.Name())
+		}
+	}
+
+	for _, prev := range c.argSpecs {
+		if prev.Name == spec.Name {
+			return fmt.Errorf("argument '%s' already exists in '%s'", spec.Name, c.Name())
+		}
+	}
+
+	if n := len(c.argSpecs); n > 0 {
+		last := c.argSpecs[n-1]
+		if last.Variadic {
+			return fmt.Errorf("argument '%s' cannot follow variadic argument '%s' in '%s'", spec.Name, last.Name, c.Name())
+		}
+		if spec.Required && !last.Required {
+			return fmt.Errorf("required argument '%s' cannot follow optional argument '%s' in '%s'", spec.Name, last.Name, c.Name())
+		}
+	}
+
+	c.argSpecs = append(c.argSpecs, spec)
+	return nil
+}
+
+// ArgSpecs 获取所有位置参数声明
+//
+// 返回值:
+//   - []types.ArgSpec: 位置参数声明的切片副本
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 按声明顺序返回
+//   - 支持并发安全的访问
+func (c *Cmd) ArgSpecs() []types.ArgSpec {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]types.ArgSpec, len(c.argSpecs))
+	copy(result, c.argSpecs)
+	return result
+}
+
+// addArg 添加位置参数声明, 失败时panic
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数描述
+//   - required: 是否必需
+//   - variadic: 是否为可变参数
+//   - value: 值容器
+func (c *Cmd) addArg(name, description string, required, variadic bool, value types.Flag) {
+	spec := types.ArgSpec{
+		Name:     name,
+		Desc:     description,
+		Required: required,
+		Variadic: variadic,
+		Value:    value,
+	}
+	if err := c.AddArg(spec); err != nil {
+		panic(err)
+	}
+}
+
+// StringArg 创建字符串位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.StringFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) StringArg(name, description string, required bool, default_ string) *flag.StringFlag {
+	f := flag.NewStringFlag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// IntArg 创建整数位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.IntFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) IntArg(name, description string, required bool, default_ int) *flag.IntFlag {
+	f := flag.NewIntFlag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// Int64Arg 创建64位整数位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.Int64Flag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) Int64Arg(name, description string, required bool, default_ int64) *flag.Int64Flag {
+	f := flag.NewInt64Flag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// UintArg 创建无符号整数位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.UintFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) UintArg(name, description string, required bool, default_ uint) *flag.UintFlag {
+	f := flag.NewUintFlag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// Float64Arg 创建浮点数位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.Float64Flag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) Float64Arg(name, description string, required bool, default_ float64) *flag.Float64Flag {
+	f := flag.NewFloat64Flag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// EnumArg 创建枚举位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//   - allowedValues: 允许的枚举值列表
+//
+// 返回值:
+//   - *flag.EnumFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) EnumArg(name, description string, required bool, default_ string, allowedValues []string) *flag.EnumFlag {
+	f := flag.NewEnumFlag(name, "", description, default_, allowedValues)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// DurationArg 创建持续时间位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.DurationFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) DurationArg(name, description string, required bool, default_ time.Duration) *flag.DurationFlag {
+	f := flag.NewDurationFlag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// TimeArg 创建时间位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.TimeFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) TimeArg(name, description string, required bool, default_ time.Time) *flag.TimeFlag {
+	f := flag.NewTimeFlag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// SizeArg 创建大小位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否必需
+//   - default_: 可选参数未提供时使用的默认值
+//
+// 返回值:
+//   - *flag.SizeFlag: 参数的值容器, 解析后通过 Get 获取值
+func (c *Cmd) SizeArg(name, description string, required bool, default_ int64) *flag.SizeFlag {
+	f := flag.NewSizeFlag(name, "", description, default_)
+	c.addArg(name, description, required, false, f)
+	return f
+}
+
+// StringSliceArg 创建字符串可变位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否至少需要一个参数
+//
+// 返回值:
+//   - *flag.StringSliceFlag: 参数的值容器, 解析后通过 Get 获取值
+//
+// 注意事项:
+//   - 可变参数接收剩余的所有位置参数, 只能是最后一个参数
+//   - 每个位置参数作为一个元素, 不按逗号分割
+func (c *Cmd) StringSliceArg(name, description string, required bool) *flag.StringSliceFlag {
+	f := flag.NewStringSliceFlag(name, "", description, []string{})
+	c.addArg(name, description, required, true, f)
+	return f
+}
+
+// IntSliceArg 创建整数可变位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否至少需要一个参数
+//
+// 返回值:
+//   - *flag.IntSliceFlag: 参数的值容器, 解析后通过 Get 获取值
+//
+// 注意事项:
+//   - 可变参数接收剩余的所有位置参数, 只能是最后一个参数
+//   - 每个位置参数作为一个元素, 不按逗号分割
+func (c *Cmd) IntSliceArg(name, description string, required bool) *flag.IntSliceFlag {
+	f := flag.NewIntSliceFlag(name, "", description, []int{})
+	c.addArg(name, description, required, true, f)
+	return f
+}
+
+// Int64SliceArg 创建64位整数可变位置参数
+//
+// 参数:
+//   - name: 参数名称
+//   - description: 参数的描述信息
+//   - required: 是否至少需要一个参数
+//
+// 返回值:
+//   - *flag.Int64SliceFlag: 参数的值容器, 解析后通过 Get 获取值
+//
+// 注意事项:
+//   - 可变参数接收剩余的所有位置参数, 只能是最后一个参数
+//   - 每个位置参数作为一个元素, 不按逗号分割
+func (c *Cmd) Int64SliceArg(name, description string, required bool) *flag.Int64SliceFlag {
+	f := flag.NewInt64SliceFlag(name, "", description, []int64{})
+	c.addArg(name, description, required, true, f)
+	return f
+}
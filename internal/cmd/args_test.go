@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gitee.com/MM-Q/qflag/internal/flag"
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+/*
+本测试文件验证位置参数声明 (ArgSpec) 的各种场景，包括：
+
+1. 声明验证：
+   - 验证重复名称、必需参数在可选参数之后、可变参数之后继续声明等错误
+
+2. 绑定验证：
+   - 验证必需、可选和可变参数的绑定结果
+   - 验证参数个数和参数类型错误由 Parse 返回
+
+3. 帮助信息验证：
+   - 验证用法行和参数部分由声明生成
+*/
+
+// newCopyCmd 创建带位置参数的测试命令
+func newCopyCmd() (*Cmd, *flag.StringFlag, *flag.IntFlag, *flag.StringSliceFlag) {
+	c := NewCmd("cp", "", types.ContinueOnError)
+	dst := c.StringArg("dst", "目标路径", true, "")
+	mode := c.IntArg("mode", "权限", true, 0)
+	srcs := c.StringSliceArg("src", "源文件", false)
+	return c, dst, mode, srcs
+}
+
+// TestArgs_Bind 测试位置参数绑定
+func TestArgs_Bind(t *testing.T) {
+	c, dst, mode, srcs := newCopyCmd()
+
+	if err := c.Parse([]string{"out", "644", "a,b", "c"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if dst.Get() != "out" || mode.Get() != 644 {
+		t.Errorf("unexpected values: dst=%q mode=%d", dst.Get(), mode.Get())
+	}
+	if got := srcs.Get(); len(got) != 2 || got[0] != "a,b" || got[1] != "c" {
+		t.Errorf("src: expected [a,b c], got %v", got)
+	}
+	if c.NArg() != 4 {
+		t.Errorf("Args should keep all positionals, got %v", c.Args())
+	}
+}
+
+// TestArgs_Errors 测试参数个数和类型错误
+func TestArgs_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"缺少必需参数", []string{"out"}, "missing required argument <mode>"},
+		{"类型错误", []string{"out", "abc"}, "invalid value \"abc\" for argument <mode>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _, _, _ := newCopyCmd()
+			err := c.Parse(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	c := NewCmd("get", "", types.ContinueOnError)
+	c.StringArg("key", "键", true, "")
+	if err := c.Parse([]string{"a", "b"}); err == nil || !strings.Contains(err.Error(), "too many arguments") {
+		t.Errorf("expected too many arguments error, got %v", err)
+	}
+}
+
+// TestArgs_Optional 测试可选参数的默认值和重复解析
+func TestArgs_Optional(t *testing.T) {
+	c := NewCmd("log", "", types.ContinueOnError)
+	level := c.EnumArg("level", "日志级别", false, "info", []string{"debug", "info"})
+
+	if err := c.Parse([]string{"debug"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if level.Get() != "debug" {
+		t.Errorf("level: expected 'debug', got %q", level.Get())
+	}
+
+	if err := c.Parse([]string{}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if level.Get() != "info" {
+		t.Errorf("level should be reset to default, got %q", level.Get())
+	}
+
+	if err := c.Parse([]string{"trace"}); err == nil {
+		t.Error("expected error for value outside enum")
+	}
+}
+
+// TestArgs_Validator 测试位置参数验证器
+func TestArgs_Validator(t *testing.T) {
+	c := NewCmd("serve", "", types.ContinueOnError)
+	port := c.IntArg("port", "端口", true, 0)
+	port.SetValidator(func(v int) error {
+		if v < 1 || v > 65535 {
+			return fmt.Errorf("port out of range: %d", v)
+		}
+		return nil
+	})
+
+	if err := c.Parse([]string{"70000"}); err == nil {
+		t.Error("expected validator error")
+	}
+	if err := c.Parse([]string{"8080"}); err != nil {
+		t.Errorf("Parse error: %v", err)
+	}
+}
+
+// TestArgs_AddArgErrors 测试无效的位置参数声明
+func TestArgs_AddArgErrors(t *testing.T) {
+	c := NewCmd("test", "", types.ContinueOnError)
+	c.StringArg("a", "", false, "")
+
+	tests := []struct {
+		name string
+		spec types.ArgSpec
+	}{
+		{"空名称", types.ArgSpec{Value: flag.NewStringFlag("x", "", "", "")}},
+		{"空值容器", types.ArgSpec{Name: "x"}},
+		{"重复名称", types.ArgSpec{Name: "a", Value: flag.NewStringFlag("a", "", "", "")}},
+		{"必需在可选之后", types.ArgSpec{Name: "b", Required: true, Value: flag.NewStringFlag("b", "", "", "")}},
+		{"可变参数非切片", types.ArgSpec{Name: "c", Variadic: true, Value: flag.NewStringFlag("c", "", "", "")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := c.AddArg(tt.spec); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	c.StringSliceArg("rest", "", false)
+	if err := c.AddArg(types.ArgSpec{Name: "d", Value: flag.NewStringFlag("d", "", "", "")}); err == nil {
+		t.Error("expected error when adding argument after variadic")
+	}
+}
+
+// TestArgs_Help 测试用法行和参数帮助信息
+func TestArgs_Help(t *testing.T) {
+	c, _, _, _ := newCopyCmd()
+	help := c.Help()
+
+	if !strings.Contains(help, "cp [options] <dst> <mode> [src...]") {
+		t.Errorf("usage line not generated from specs:\n%s", help)
+	}
+	if !strings.Contains(help, "Arguments:") || !strings.Contains(help, "dst <string>") {
+		t.Errorf("help should contain arguments section:\n%s", help)
+	}
+}
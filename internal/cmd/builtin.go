@@ -33,11 +33,12 @@ func createCompleteCmd(root *Cmd) error {
 		Hidden:             true, // 隐藏在命令列表中
 		DisableFlagParsing: true, // 禁用标志解析，只处理指令参数
 		Examples: map[string]string{
-			"执行模糊匹配补全": fmt.Sprintf("%s %s %s <模式> <候选1> [候选2] ...", root.Name(), types.CompleteCmdName, types.InstructionFuzzy),
-			"计算上下文路径":  fmt.Sprintf("%s %s %s <arg0> [arg1] ...", root.Name(), types.CompleteCmdName, types.InstructionContext),
-			"获取候选选项":   fmt.Sprintf("%s %s %s <上下文路径>", root.Name(), types.CompleteCmdName, types.InstructionCandidates),
-			"获取枚举值":    fmt.Sprintf("%s %s %s <上下文路径> <标志名>", root.Name(), types.CompleteCmdName, types.InstructionEnum),
-			"统一获取补全信息": fmt.Sprintf("%s %s %s <当前输入> <前一个输入> [子命令参数...]", root.Name(), types.CompleteCmdName, types.InstructionAll),
+			"执行模糊匹配补全":   fmt.Sprintf("%s %s %s <模式> <候选1> [候选2] ...", root.Name(), types.CompleteCmdName, types.InstructionFuzzy),
+			"计算上下文路径":    fmt.Sprintf("%s %s %s <arg0> [arg1] ...", root.Name(), types.CompleteCmdName, types.InstructionContext),
+			"获取候选选项":     fmt.Sprintf("%s %s %s <上下文路径>", root.Name(), types.CompleteCmdName, types.InstructionCandidates),
+			"获取枚举值":      fmt.Sprintf("%s %s %s <上下文路径> <标志名>", root.Name(), types.CompleteCmdName, types.InstructionEnum),
+			"统一获取补全信息":   fmt.Sprintf("%s %s %s <当前输入> <前一个输入> [子命令参数...]", root.Name(), types.CompleteCmdName, types.InstructionAll),
+			"获取JSON补全信息": fmt.Sprintf("%s %s %s <当前输入> <前一个输入> [子命令参数...]", root.Name(), types.CompleteCmdName, types.InstructionJSON),
 		},
 		Notes: []string{
 			"本命令为内部命令，用于 Shell 自动补全脚本动态获取补全信息",
@@ -48,6 +49,10 @@ func createCompleteCmd(root *Cmd) error {
 			"",
 			"生产模式：",
 			"  默认情况下所有错误都被静默处理，避免干扰补全脚本解析",
+			"",
+			"输出格式：",
+			fmt.Sprintf("  %s 指令输出带版本号的 JSON，新生成的补全脚本使用该格式，", types.InstructionJSON),
+			fmt.Sprintf("  设置环境变量 %s=%s 可使 %s 指令同样输出 JSON", types.CompleteFormatEnv, types.CompleteFormatJSON, types.InstructionAll),
 		},
 		RunFunc: func(c types.Command) error {
 			args := c.Args()
@@ -15,13 +15,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"sync"
 
 	"gitee.com/MM-Q/qflag/internal/help"
 	"gitee.com/MM-Q/qflag/internal/parser"
 	"gitee.com/MM-Q/qflag/internal/registry"
 	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
 )
 
 // Cmd 是一个命令结构体, 实现了 types.Command 接口
@@ -43,17 +48,26 @@ type Cmd struct {
 	hidden             bool             // 是否隐藏命令, 隐藏的命令不会显示在帮助信息中
 	disableFlagParsing bool             // 是否禁用标志解析, 禁用后所有参数都作为位置参数处理
 
-	flagRegistry types.FlagRegistry // 标志注册器, 管理命令的所有标志
-	cmdRegistry  types.CmdRegistry  // 子命令注册器, 管理所有子命令
+	flagRegistry       types.FlagRegistry // 标志注册器, 管理命令的所有标志
+	persistentRegistry types.FlagRegistry // 持久标志注册器, 管理可被子孙命令继承的标志
+	cmdRegistry        types.CmdRegistry  // 子命令注册器, 管理所有子命令
 
-	args      []string  // 命令行参数列表
-	parsed    bool      // 标记是否已解析命令行参数, false 表示未解析, true 表示已解析
-	parseOnce sync.Once // 确保解析只执行一次
+	args      []string        // 命令行参数列表
+	argSpecs  []types.ArgSpec // 位置参数声明列表
+	parsed    bool            // 标记是否已解析命令行参数, false 表示未解析, true 表示已解析
+	parseOnce sync.Once       // 确保解析只执行一次
+	ctx       context.Context // 执行上下文, 为nil时继承父命令
 
-	runFunc func(types.Command) error // 命令的运行函数, 用于执行命令逻辑, 返回错误信息或 nil
+	runFunc               func(types.Command) error // 命令的运行函数, 用于执行命令逻辑, 返回错误信息或 nil
+	preRunFunc            func(types.Command) error // 前置钩子, 在运行函数之前执行
+	postRunFunc           func(types.Command) error // 后置钩子, 在运行函数之后执行
+	persistentPreRunFunc  func(types.Command) error // 持久前置钩子, 在自身及所有子孙命令运行前执行
+	persistentPostRunFunc func(types.Command) error // 持久后置钩子, 在自身及所有子孙命令运行后执行
+	middlewares           []types.Middleware        // 运行中间件, 包装自身及所有子孙命令的运行函数
 
-	parser types.Parser // 解析器, 用于解析命令行参数
-	parent *Cmd         // 父命令引用, 用于构建命令树, 默认为 nil
+	parser        types.Parser        // 解析器, 用于解析命令行参数
+	errorHandling types.ErrorHandling // 错误处理策略
+	parent        *Cmd                // 父命令引用, 用于构建命令树, 默认为 nil
 }
 
 // NewCmd 创建新的命令实例
@@ -68,22 +82,32 @@ type Cmd struct {
 //
 // 功能说明:
 //   - 创建命令并初始化基本字段
-//   - 创建标志和子命令注册器
+//   - 创建标志、持久标志和子命令注册器
 //   - 设置默认解析器
 //   - 初始化配置选项
 func NewCmd(longName, shortName string, errorHandling types.ErrorHandling) *Cmd {
 	return &Cmd{
-		longName:     longName,
-		shortName:    shortName,
-		config:       types.NewCmdConfig(),
-		flagRegistry: registry.NewFlagRegistry(),
-		cmdRegistry:  registry.NewCmdRegistry(),
-		args:         []string{},
-		parsed:       false,
-		parser:       parser.NewDefaultParser(errorHandling),
+		longName:           longName,
+		shortName:          shortName,
+		config:             types.NewCmdConfig(),
+		flagRegistry:       registry.NewFlagRegistry(),
+		persistentRegistry: registry.NewFlagRegistry(),
+		cmdRegistry:        registry.NewCmdRegistry(),
+		args:               []string{},
+		parsed:             false,
+		parser:             parser.NewDefaultParser(errorHandling),
+		errorHandling:      errorHandling,
 	}
 }
 
+// ErrorHandling 获取命令的错误处理策略
+//
+// 返回值:
+//   - types.ErrorHandling: 创建命令时指定的错误处理策略
+func (c *Cmd) ErrorHandling() types.ErrorHandling {
+	return c.errorHandling
+}
+
 // Name 获取命令名称
 //
 // 返回值:
@@ -267,6 +291,29 @@ func (c *Cmd) Flags() []types.Flag {
 	return result
 }
 
+// FlagOrigins 获取命令所有标志的值来源
+//
+// 返回值:
+//   - []types.FlagOrigin: 标志及其值来源列表, 先列出自身标志, 再列出继承的持久标志
+//
+// 功能说明:
+//   - 用于输出生效配置或排查命令行参数、环境变量、配置文件和默认值之间的优先级问题
+//   - 来源信息在解析时记录, 解析前所有标志均为默认值来源
+//   - 支持并发安全的访问
+func (c *Cmd) FlagOrigins() []types.FlagOrigin {
+	flags := c.Flags()
+	inherited := c.InheritedFlags()
+
+	result := make([]types.FlagOrigin, 0, len(flags)+len(inherited))
+	for _, f := range flags {
+		result = append(result, types.FlagOrigin{Flag: f, Origin: f.Origin()})
+	}
+	for _, f := range inherited {
+		result = append(result, types.FlagOrigin{Flag: f, Inherited: true, Origin: f.Origin()})
+	}
+	return result
+}
+
 // AddSubCmds 添加子命令到命令
 //
 // 参数:
@@ -484,6 +531,132 @@ func (c *Cmd) ParseAndRouteOnce(args []string) error {
 	return err
 }
 
+// ParseAndRouteContext 使用上下文解析并路由执行命令
+//
+// 参数:
+//   - ctx: 执行上下文, 运行函数和钩子可以通过 Context() 读取
+//   - args: 命令行参数列表
+//
+// 返回值:
+//   - error: 解析或执行失败时返回错误, 上下文在执行前已取消时返回取消原因
+//
+// 功能说明:
+//   - 设置命令的执行上下文后调用解析器的ParseAndRoute方法
+//   - 子命令未设置自己的上下文时继承该上下文
+//   - 配合 SignalContext 可以在收到 Ctrl-C 时取消执行
+func (c *Cmd) ParseAndRouteContext(ctx context.Context, args []string) error {
+	c.SetContext(ctx)
+	return c.parser.ParseAndRoute(c, args)
+}
+
+// Execute 解析并路由执行命令, 失败时输出错误并按退出码退出程序
+//
+// 参数:
+//   - args: 命令行参数列表
+//
+// 功能说明:
+//   - 使用命令当前的上下文调用 ExecuteContext
+//   - 适合作为 main 函数的唯一入口, 成功时正常返回
+func (c *Cmd) Execute(args []string) {
+	c.ExecuteContext(c.Context(), args)
+}
+
+// ExecuteContext 使用上下文解析并路由执行命令, 失败时输出错误并按退出码退出程序
+//
+// 参数:
+//   - ctx: 执行上下文
+//   - args: 命令行参数列表
+//
+// 功能说明:
+//   - 执行成功或用户请求了帮助、版本、补全时正常返回, 不退出程序
+//   - 失败时按命令的语言设置输出错误信息到 ErrOut (开启中文时, 实现 types.LocalizedError 的错误输出中文信息),
+//     再按 types.ExitCodeOf 映射的退出码退出:
+//     调用方式错误为 2, 值验证错误为 SetValidationExitCode 设置的值, 实现 types.ExitCoder 的错误使用自身的退出码,
+//     收到信号或超时为 128+信号编号 或 124, 其他错误为 1
+//   - 执行期间由 ExecuteContext 统一处理错误, 忽略命令的错误处理策略, 解析器不再单独输出错误和帮助信息
+func (c *Cmd) ExecuteContext(ctx context.Context, args []string) {
+	if code := c.execute(ctx, args); code != types.ExitCodeOK {
+		os.Exit(code)
+	}
+}
+
+// execute 执行命令并返回退出码
+//
+// 参数:
+//   - ctx: 执行上下文
+//   - args: 命令行参数列表
+//
+// 返回值:
+//   - int: 退出码, 成功时为 types.ExitCodeOK
+//
+// 功能说明:
+//   - 执行结束后恢复命令原来的上下文
+//   - 值验证错误的退出码取自路由到的命令, 子命令可以通过 SetValidationExitCode 覆盖父命令的设置
+//   - 退出码不为0时输出错误信息
+func (c *Cmd) execute(ctx context.Context, args []string) int {
+	c.mu.RLock()
+	prev := c.ctx
+	c.mu.RUnlock()
+	defer c.SetContext(prev)
+
+	// 运行函数返回的 context.Canceled 不带信号信息, 从上下文的取消原因中补充
+	execCtx := utils.WithExecute(ctx)
+	err := utils.SignalCause(ctx, c.ParseAndRouteContext(execCtx, args))
+
+	// 按返回错误的命令 (可能是子命令) 的 SetValidationExitCode 设置映射退出码
+	code := utils.ExitCode(utils.RoutedCommand(execCtx, c), err)
+	if code == types.ExitCodeOK {
+		return code
+	}
+
+	// 部分错误信息以换行结尾 (如带建议列表的未知标志错误), 去掉多余的空行
+	chinese := c.Config().UseChinese
+	msg := strings.TrimRight(types.LocalizedMessage(err, chinese), "\n")
+	if chinese {
+		fmt.Fprintf(c.ErrOut(), "错误: %s\n", msg)
+	} else {
+		fmt.Fprintf(c.ErrOut(), "Error: %s\n", msg)
+	}
+	return code
+}
+
+// Context 获取命令的执行上下文
+//
+// 返回值:
+//   - context.Context: 执行上下文
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 返回通过 ParseAndRouteContext 或 SetContext 设置的上下文
+//   - 未设置时继承父命令的上下文, 根命令也未设置时返回 context.Background()
+//   - 运行函数和钩子中通过该方法获取取消信号和截止时间
+func (c *Cmd) Context() context.Context {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.ctx != nil {
+		return c.ctx
+	}
+	if c.parent != nil {
+		return c.parent.Context()
+	}
+	return context.Background()
+}
+
+// SetContext 设置命令的执行上下文
+//
+// 参数:
+//   - ctx: 执行上下文, 为nil时恢复为继承父命令
+//
+// 功能说明:
+//   - 通常由 ParseAndRouteContext 调用, 也可以在 ParseAndRoute 之前手动设置
+//   - 支持并发安全的设置
+func (c *Cmd) SetContext(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ctx = ctx
+}
+
 // ParseOnly 仅解析当前命令, 不递归解析子命令 (可重复解析)
 //
 // 参数:
@@ -649,6 +822,55 @@ func (c *Cmd) HasRunFunc() bool {
 	return c.runFunc != nil
 }
 
+// Hook 获取指定类型的生命周期钩子
+//
+// 参数:
+//   - t: 钩子类型
+//
+// 返回值:
+//   - func(types.Command) error: 钩子函数, 未设置时返回nil
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 供解析器在路由执行时按顺序调用钩子
+func (c *Cmd) Hook(t types.HookType) func(types.Command) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch t {
+	case types.PersistentPreRunHook:
+		return c.persistentPreRunFunc
+	case types.PreRunHook:
+		return c.preRunFunc
+	case types.PostRunHook:
+		return c.postRunFunc
+	case types.PersistentPostRunHook:
+		return c.persistentPostRunFunc
+	default:
+		return nil
+	}
+}
+
+// Middlewares 获取命令自身注册的运行中间件
+//
+// 返回值:
+//   - []types.Middleware: 中间件列表的副本, 按注册顺序排列
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 不包含祖先命令的中间件, 路由路径上的中间件由解析器组合
+func (c *Cmd) Middlewares() []types.Middleware {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.middlewares) == 0 {
+		return nil
+	}
+	mws := make([]types.Middleware, len(c.middlewares))
+	copy(mws, c.middlewares)
+	return mws
+}
+
 // Help 获取帮助信息
 //
 // 返回值:
@@ -669,11 +891,51 @@ func (c *Cmd) Help() string {
 //
 // 功能说明:
 //   - 实现types.Command接口
-//   - 直接输出帮助信息到控制台
-//   - 使用标准fmt包输出
+//   - 输出帮助信息到命令的标准输出 (见 Out)
 //   - 支持并发安全的访问
 func (c *Cmd) PrintHelp() {
-	fmt.Println(help.GenHelp(c))
+	fmt.Fprintln(c.Out(), help.GenHelp(c))
+}
+
+// Out 获取命令的标准输出
+//
+// 返回值:
+//   - io.Writer: 标准输出
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 返回命令自身或最近的祖先命令通过 SetOut 设置的输出
+//   - 都未设置时返回 os.Stdout
+//   - 帮助信息、版本信息、补全脚本等内置输出都写入此处
+func (c *Cmd) Out() io.Writer {
+	return utils.Stdout(c)
+}
+
+// ErrOut 获取命令的标准错误输出
+//
+// 返回值:
+//   - io.Writer: 标准错误输出
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 返回命令自身或最近的祖先命令通过 SetErr 设置的输出
+//   - 都未设置时返回 os.Stderr
+//   - 解析错误信息写入此处
+func (c *Cmd) ErrOut() io.Writer {
+	return utils.Stderr(c)
+}
+
+// In 获取命令的标准输入
+//
+// 返回值:
+//   - io.Reader: 标准输入
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 返回命令自身或最近的祖先命令通过 SetIn 设置的输入
+//   - 都未设置时返回 os.Stdin
+func (c *Cmd) In() io.Reader {
+	return utils.Stdin(c)
 }
 
 // IsHidden 检查命令是否隐藏
@@ -754,6 +1016,25 @@ func (c *Cmd) IsRootCmd() bool {
 	return c.parent == nil
 }
 
+// Parent 获取父命令
+//
+// 返回值:
+//   - types.Command: 父命令, 根命令返回nil
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 用于沿命令树向上查找继承的配置
+//   - 支持并发安全的访问
+func (c *Cmd) Parent() types.Command {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.parent == nil {
+		return nil
+	}
+	return c.parent
+}
+
 // Path 获取命令路径
 //
 // 返回值:
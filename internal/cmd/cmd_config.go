@@ -12,8 +12,13 @@
 // 主要方法列表:
 //   - Config: 获取命令配置
 //   - SetDesc/SetHidden/SetDisableFlagParsing: 设置基本属性
-//   - SetVersion/SetChinese/SetCompletion: 设置配置选项
+//   - SetVersion/SetChinese/SetCompletion/SetInterspersed/SetAllowAbbrev/SetResponseFiles/SetCollectErrors: 设置配置选项
+//   - SetConfigFile/SetConfigDecoder: 设置配置文件来源
+//   - SetOut/SetErr/SetIn: 设置标准输出、标准错误输出和标准输入
+//   - SetValidationExitCode: 设置值验证错误的退出码
 //   - SetParser/SetArgs/SetParsed/SetRun: 设置解析器和运行函数
+//   - SetPreRun/SetPostRun/SetPersistentPreRun/SetPersistentPostRun: 设置生命周期钩子
+//   - Use: 注册运行中间件
 //   - AddExample/AddExamples/AddNote/AddNotes: 添加示例和注释
 //   - ApplyOpts: 批量应用选项到命令
 //
@@ -24,6 +29,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 
 	"gitee.com/MM-Q/qflag/internal/types"
 )
@@ -125,6 +131,170 @@ func (c *Cmd) SetDisableFlagParsing(disable bool) {
 	c.disableFlagParsing = disable
 }
 
+// SetInterspersed 设置是否允许标志与位置参数交替出现
+//
+// 参数:
+//   - enable: 是否允许交替出现
+//
+// 功能说明:
+//   - 默认关闭, 遇到第一个位置参数时停止解析标志
+//   - 开启后, 直到 -- 之前的标志都会被解析, 无论是否位于位置参数之后
+//   - Args() 只返回真正的位置参数, 并保持原有顺序
+//   - 只对当前命令生效, 子命令需要单独设置
+//   - 支持并发安全的设置
+func (c *Cmd) SetInterspersed(enable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.Interspersed = enable
+}
+
+// SetAllowAbbrev 设置是否允许缩写长标志和子命令
+//
+// 参数:
+//   - enable: 是否允许缩写
+//
+// 功能说明:
+//   - 默认关闭, 长标志和子命令必须完整输入
+//   - 开启后, 唯一前缀可以代替完整名称, 如 --verb 等同于 --verbose, ser 等同于 server
+//   - 前缀匹配多个名称时返回歧义错误, 并列出候选项; 完整名称始终优先
+//   - 对当前命令及其所有子孙命令生效
+//   - 支持并发安全的设置
+func (c *Cmd) SetAllowAbbrev(enable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.AllowAbbrev = enable
+}
+
+// SetResponseFiles 设置是否展开响应文件
+//
+// 参数:
+//   - enable: 是否展开响应文件
+//
+// 功能说明:
+//   - 默认关闭
+//   - 开启后, 解析前将 @path 形式的参数替换为文件中的参数列表
+//   - 文件支持引号、注释和嵌套的响应文件; @@ 开头的参数表示以 @ 开头的字面参数
+//   - 对当前命令及其所有子孙命令生效, 禁用标志解析的命令不展开
+//   - 支持并发安全的设置
+func (c *Cmd) SetResponseFiles(enable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.ResponseFiles = enable
+}
+
+// SetCollectErrors 设置是否开启错误收集模式
+//
+// 参数:
+//   - enable: 是否收集所有错误
+//
+// 功能说明:
+//   - 默认关闭, 解析遇到第一个错误即返回
+//   - 开启后, 一次解析中的无效值、未知标志、环境变量和配置文件错误、
+//     互斥组/必需组/依赖关系违规都会被收集, 汇总为 types.ParseErrors 返回
+//   - 汇总的错误按命令的错误处理策略处理
+//   - 对当前命令及其所有子孙命令生效
+//   - 支持并发安全的设置
+func (c *Cmd) SetCollectErrors(enable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.CollectErrors = enable
+}
+
+// SetConfigFile 设置配置文件路径
+//
+// 参数:
+//   - path: 配置文件路径, 为空表示不使用配置文件
+//
+// 功能说明:
+//   - 解析时从配置文件加载未被命令行参数和环境变量设置的标志
+//   - 优先级: 命令行参数 > 环境变量 > 配置文件 > 默认值
+//   - 配置键对应标志的长名称, 子命令的标志位于按 Path() 逐级嵌套的分区中
+//   - 对当前命令及其所有子孙命令生效, 子孙命令可以设置自己的配置文件覆盖
+//   - 配置文件不存在时跳过加载
+//   - 支持并发安全的设置
+func (c *Cmd) SetConfigFile(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.ConfigFile = path
+}
+
+// SetConfigDecoder 设置配置文件解码器
+//
+// 参数:
+//   - decoder: 配置文件解码器, 为nil时按文件扩展名选择内置解码器
+//
+// 功能说明:
+//   - 内置解码器: .json 使用 JSON 格式, .toml/.ini/.conf/.cfg 使用类 TOML/INI 格式
+//   - 其他格式 (如 YAML) 可以实现 types.ConfigDecoder 接口后在此设置
+//   - 支持并发安全的设置
+func (c *Cmd) SetConfigDecoder(decoder types.ConfigDecoder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.ConfigDecoder = decoder
+}
+
+// SetOut 设置命令的标准输出
+//
+// 参数:
+//   - w: 标准输出, 为nil时恢复为继承父命令或使用 os.Stdout
+//
+// 功能说明:
+//   - 帮助信息、版本信息、补全脚本、__complete 协议输出和安装提示都写入此处
+//   - 对当前命令及其所有子孙命令生效, 子孙命令可以设置自己的输出覆盖
+//   - 支持并发安全的设置
+func (c *Cmd) SetOut(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.Out = w
+}
+
+// SetErr 设置命令的标准错误输出
+//
+// 参数:
+//   - w: 标准错误输出, 为nil时恢复为继承父命令或使用 os.Stderr
+//
+// 功能说明:
+//   - 解析错误信息写入此处
+//   - 对当前命令及其所有子孙命令生效, 子孙命令可以设置自己的输出覆盖
+//   - 支持并发安全的设置
+func (c *Cmd) SetErr(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.Err = w
+}
+
+// SetIn 设置命令的标准输入
+//
+// 参数:
+//   - r: 标准输入, 为nil时恢复为继承父命令或使用 os.Stdin
+//
+// 功能说明:
+//   - 运行函数可以通过 In() 读取, 便于在测试或服务中替换输入
+//   - 对当前命令及其所有子孙命令生效, 子孙命令可以设置自己的输入覆盖
+//   - 支持并发安全的设置
+func (c *Cmd) SetIn(r io.Reader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.In = r
+}
+
+// SetValidationExitCode 设置值验证错误的退出码
+//
+// 参数:
+//   - code: 退出码, 为0时恢复为继承父命令或使用 types.ExitCodeUsage (2)
+//
+// 功能说明:
+//   - 值验证错误指标志或位置参数的值无效、违反互斥组/必需组/依赖关系规则 (见 types.IsValidationError)
+//   - 用于让脚本区分"参数值不合法"和其他调用错误, 如设置为 65 (EX_DATAERR)
+//   - 在 Execute 和 ExitOnError 策略下退出程序时使用
+//   - 对当前命令及其所有子孙命令生效
+//   - 支持并发安全的设置
+func (c *Cmd) SetValidationExitCode(code int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.ValidationExitCode = code
+}
+
 // SetVersion 设置命令版本
 //
 // 参数:
@@ -312,6 +482,90 @@ func (c *Cmd) SetRun(fn func(types.Command) error) {
 	c.runFunc = fn
 }
 
+// SetPreRun 设置命令的前置钩子
+//
+// 参数:
+//   - fn: 钩子函数, 接收当前命令, 返回错误时中止执行
+//
+// 功能说明:
+//   - 通过 ParseAndRoute 执行当前命令时, 在运行函数之前调用
+//   - 在所有 PersistentPreRun 之后调用
+//   - 只对当前命令生效, 不影响子命令
+//   - 支持并发安全的设置
+func (c *Cmd) SetPreRun(fn func(types.Command) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.preRunFunc = fn
+}
+
+// SetPostRun 设置命令的后置钩子
+//
+// 参数:
+//   - fn: 钩子函数, 接收当前命令
+//
+// 功能说明:
+//   - 通过 ParseAndRoute 执行当前命令时, 在运行函数之后调用
+//   - 运行函数返回错误时仍会调用, 用于清理资源
+//   - 只对当前命令生效, 不影响子命令
+//   - 支持并发安全的设置
+func (c *Cmd) SetPostRun(fn func(types.Command) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.postRunFunc = fn
+}
+
+// SetPersistentPreRun 设置命令的持久前置钩子
+//
+// 参数:
+//   - fn: 钩子函数, 接收实际执行的命令 (可能是子孙命令), 返回错误时中止执行
+//
+// 功能说明:
+//   - 执行当前命令或任一子孙命令时调用, 适合日志初始化、认证、加载配置等公共准备工作
+//   - 路由路径上的所有持久前置钩子按从根命令到执行命令的顺序调用
+//   - 支持并发安全的设置
+func (c *Cmd) SetPersistentPreRun(fn func(types.Command) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.persistentPreRunFunc = fn
+}
+
+// SetPersistentPostRun 设置命令的持久后置钩子
+//
+// 参数:
+//   - fn: 钩子函数, 接收实际执行的命令 (可能是子孙命令)
+//
+// 功能说明:
+//   - 执行当前命令或任一子孙命令后调用, 运行函数返回错误时仍会调用
+//   - 路由路径上的所有持久后置钩子按从执行命令到根命令的顺序调用
+//   - 支持并发安全的设置
+func (c *Cmd) SetPersistentPostRun(fn func(types.Command) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.persistentPostRunFunc = fn
+}
+
+// Use 注册运行中间件
+//
+// 参数:
+//   - mws: 中间件列表, nil 会被忽略
+//
+// 功能说明:
+//   - 中间件包装当前命令及所有子孙命令的运行函数, 适合计时、panic 恢复、审计日志、功能开关等
+//   - 通过 ParseAndRoute 执行时, 从根命令到执行命令依次组合路由路径上的中间件, 先注册的在外层
+//   - 中间件只包装运行函数, 不包装生命周期钩子
+//   - 可多次调用, 追加到已注册的中间件之后
+//   - 支持并发安全的设置
+func (c *Cmd) Use(mws ...types.Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, mw := range mws {
+		if mw != nil {
+			c.middlewares = append(c.middlewares, mw)
+		}
+	}
+}
+
 // AddExample 添加单个示例
 //
 // 参数:
@@ -408,7 +662,7 @@ func (c *Cmd) AddNotes(notes []string) {
 //   - 使用defer捕获panic, 转换为错误返回
 //
 // 应用顺序:
-//  1. 基本属性 (Desc、RunFunc)
+//  1. 基本属性 (Desc、RunFunc 和生命周期钩子)
 //  2. 配置选项 (Version、UseChinese、EnvPrefix、UsageSyntax、LogoText)
 //  3. 示例和说明 (Examples、Notes)
 //  4. 互斥组 (MutexGroups)
@@ -458,6 +712,21 @@ func (c *Cmd) ApplyOpts(opts *CmdOpts) error {
 	if opts.RunFunc != nil {
 		c.SetRun(opts.RunFunc)
 	}
+	if opts.PreRunFunc != nil {
+		c.SetPreRun(opts.PreRunFunc)
+	}
+	if opts.PostRunFunc != nil {
+		c.SetPostRun(opts.PostRunFunc)
+	}
+	if opts.PersistentPreRunFunc != nil {
+		c.SetPersistentPreRun(opts.PersistentPreRunFunc)
+	}
+	if opts.PersistentPostRunFunc != nil {
+		c.SetPersistentPostRun(opts.PersistentPostRunFunc)
+	}
+	if len(opts.Middlewares) > 0 {
+		c.Use(opts.Middlewares...)
+	}
 
 	// 2. 设置配置选项 - 调用现有方法
 	if opts.Version != "" {
@@ -477,6 +746,28 @@ func (c *Cmd) ApplyOpts(opts *CmdOpts) error {
 	c.SetDisableFlagParsing(opts.DisableFlagParsing)
 	c.SetCompletion(opts.Completion)
 	c.SetDynamicCompletion(opts.DynamicCompletion)
+	c.SetInterspersed(opts.Interspersed)
+	c.SetAllowAbbrev(opts.AllowAbbrev)
+	c.SetResponseFiles(opts.ResponseFiles)
+	c.SetCollectErrors(opts.CollectErrors)
+	if opts.ConfigFile != "" {
+		c.SetConfigFile(opts.ConfigFile)
+	}
+	if opts.ConfigDecoder != nil {
+		c.SetConfigDecoder(opts.ConfigDecoder)
+	}
+	if opts.Out != nil {
+		c.SetOut(opts.Out)
+	}
+	if opts.Err != nil {
+		c.SetErr(opts.Err)
+	}
+	if opts.In != nil {
+		c.SetIn(opts.In)
+	}
+	if opts.ValidationExitCode != 0 {
+		c.SetValidationExitCode(opts.ValidationExitCode)
+	}
 
 	// 3. 添加示例和说明 - 调用现有方法
 	if len(opts.Examples) > 0 {
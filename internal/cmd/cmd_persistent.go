@@ -0,0 +1,172 @@
+// Package cmd 提供命令实现和命令管理功能
+//
+// cmd_persistent.go 包含持久标志 (可被子孙命令继承的标志) 相关的功能实现
+//
+// 本文件提供了以下主要功能:
+//   - 持久标志注册: 将标志声明为持久标志
+//   - 持久标志查询: 获取当前命令声明的持久标志
+//   - 继承标志查询: 获取从祖先命令继承的持久标志
+//
+// 主要方法列表:
+//   - AddPersistentFlag: 添加持久标志
+//   - MarkPersistent: 将已注册的标志标记为持久标志
+//   - PersistentFlags: 获取当前命令声明的持久标志
+//   - InheritedFlags: 获取从祖先命令继承的持久标志
+//
+// 持久标志特性:
+//   - 持久标志同时注册在命令自身的标志注册器中, 对声明命令而言与普通标志无异
+//   - 子孙命令在任意层级都接受该标志, 解析到同一个标志实例
+//   - 子孙命令的同名标志优先, 会遮蔽继承的持久标志
+//
+// 线程安全:
+//   - 所有公共方法都使用读写锁保护
+//   - 支持并发安全的访问和修改
+package cmd
+
+import (
+	"fmt"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// AddPersistentFlag 添加持久标志到命令
+//
+// 参数:
+//   - f: 要添加的标志
+//
+// 返回值:
+//   - error: 添加失败时返回错误
+//
+// 功能说明:
+//   - 将标志注册到命令的标志注册器和持久标志注册器
+//   - 持久标志可在所有子孙命令中使用
+//   - 支持并发安全的添加操作
+//
+// 错误情况:
+//   - 标志为nil: 返回错误
+//   - 标志名称冲突: 返回错误
+func (c *Cmd) AddPersistentFlag(f types.Flag) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if f == nil {
+		return fmt.Errorf("nil flag in '%s'", c.Name())
+	}
+
+	if err := c.flagRegistry.Register(f); err != nil {
+		return err
+	}
+
+	return c.persistentRegistry.Register(f)
+}
+
+// MarkPersistent 将已注册的标志标记为持久标志
+//
+// 参数:
+//   - names: 标志名称列表 (长名称或短名称)
+//
+// 返回值:
+//   - error: 标记失败时返回错误
+//
+// 功能说明:
+//   - 配合 String、Int 等便捷方法使用, 先创建标志再标记为持久标志
+//   - 已经是持久标志的会被跳过
+//   - 支持并发安全的操作
+//
+// 使用示例:
+//
+//	root.String("config", "c", "配置文件路径", "")
+//	root.MarkPersistent("config")
+func (c *Cmd) MarkPersistent(names ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		f, ok := c.flagRegistry.Get(name)
+		if !ok {
+			return fmt.Errorf("flag '%s' not found in '%s'", name, c.Name())
+		}
+
+		if c.persistentRegistry.Has(f.Name()) {
+			continue
+		}
+
+		if err := c.persistentRegistry.Register(f); err != nil {
+			return fmt.Errorf("mark flag '%s' persistent failed in '%s': %w", name, c.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// PersistentFlags 获取当前命令声明的持久标志
+//
+// 返回值:
+//   - []types.Flag: 持久标志的切片副本
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 只包含当前命令声明的持久标志, 不包含继承的标志
+//   - 支持并发安全的访问
+func (c *Cmd) PersistentFlags() []types.Flag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flags := c.persistentRegistry.List()
+	result := make([]types.Flag, len(flags))
+	copy(result, flags)
+	return result
+}
+
+// InheritedFlags 获取从祖先命令继承的持久标志
+//
+// 返回值:
+//   - []types.Flag: 继承的持久标志列表
+//
+// 功能说明:
+//   - 实现types.Command接口
+//   - 从父命令开始逐级向上收集持久标志, 距离越近越靠前
+//   - 与当前命令自身标志或更近祖先的标志同名 (长名称或短名称) 的会被跳过
+//   - 支持并发安全的访问
+func (c *Cmd) InheritedFlags() []types.Flag {
+	c.mu.RLock()
+	parent := c.parent
+	c.mu.RUnlock()
+
+	taken := make(map[string]bool)
+	for _, f := range c.Flags() {
+		markFlagNames(taken, f)
+	}
+
+	var result []types.Flag
+	for p := parent; p != nil; {
+		for _, f := range p.PersistentFlags() {
+			if taken[f.LongName()] || taken[f.ShortName()] {
+				continue
+			}
+			markFlagNames(taken, f)
+			result = append(result, f)
+		}
+
+		p.mu.RLock()
+		next := p.parent
+		p.mu.RUnlock()
+		p = next
+	}
+
+	return result
+}
+
+// markFlagNames 记录标志的长短名称
+//
+// 参数:
+//   - names: 名称集合
+//   - f: 标志
+func markFlagNames(names map[string]bool, f types.Flag) {
+	if f.LongName() != "" {
+		names[f.LongName()] = true
+	}
+	if f.ShortName() != "" {
+		names[f.ShortName()] = true
+	}
+}
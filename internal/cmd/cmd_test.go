@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
 )
 
 // 测试通过Cmd创建标志并打印帮助信息
@@ -633,3 +638,123 @@ func TestHelpGeneration(t *testing.T) {
 	// 测试打印帮助信息 (不会实际打印, 只是确保不崩溃)
 	cmd.PrintHelp()
 }
+
+// exitCodeErr 携带退出码的测试错误
+type exitCodeErr struct{ code int }
+
+func (e exitCodeErr) Error() string { return fmt.Sprintf("exit %d", e.code) }
+func (e exitCodeErr) ExitCode() int { return e.code }
+
+func TestCmdExecuteExitCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		runErr     error
+		chinese    bool
+		wantCode   int
+		wantStderr string
+	}{
+		{name: "success", args: nil, wantCode: 0},
+		{name: "help", args: []string{"--help"}, wantCode: 0},
+		{name: "unknown flag", args: []string{"--nope"}, wantCode: types.ExitCodeUsage, wantStderr: "Error: "},
+		{name: "missing value", args: []string{"--port"}, wantCode: types.ExitCodeUsage, wantStderr: "Error: "},
+		{name: "invalid value", args: []string{"--port", "abc"}, wantCode: 65, wantStderr: "Error: "},
+		{name: "run error", runErr: fmt.Errorf("boom"), wantCode: types.ExitCodeFailure, wantStderr: "Error: boom"},
+		{name: "exit coder", runErr: fmt.Errorf("wrapped: %w", exitCodeErr{7}), wantCode: 7, wantStderr: "Error: wrapped: exit 7"},
+		{name: "chinese", args: []string{"--nope"}, chinese: true, wantCode: types.ExitCodeUsage, wantStderr: "错误: app: 未知标志: '--nope'"},
+		{name: "chinese invalid value", args: []string{"--port", "abc"}, chinese: true, wantCode: 65, wantStderr: `错误: 标志 --port 的值 "abc" 无效: `},
+		{name: "chinese run error", runErr: fmt.Errorf("boom"), chinese: true, wantCode: types.ExitCodeFailure, wantStderr: "错误: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			cmd := NewCmd("app", "", types.ExitOnError)
+			cmd.SetOut(&stdout)
+			cmd.SetErr(&stderr)
+			cmd.SetChinese(tt.chinese)
+			cmd.SetValidationExitCode(65)
+			cmd.Int("port", "p", "port", 0)
+			cmd.SetRun(func(c types.Command) error { return tt.runErr })
+
+			if code := cmd.execute(context.Background(), tt.args); code != tt.wantCode {
+				t.Errorf("execute() code = %d, want %d (stderr: %q)", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantStderr == "" && stderr.Len() != 0 {
+				t.Errorf("unexpected stderr: %q", stderr.String())
+			}
+			if tt.wantStderr != "" && (!strings.HasPrefix(stderr.String(), tt.wantStderr) || strings.Count(stderr.String(), tt.wantStderr) != 1 || strings.HasSuffix(stderr.String(), "\n\n")) {
+				t.Errorf("stderr = %q, want one message starting with %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+
+	// 子命令的值验证错误按子命令的设置映射退出码, 未设置时继承父命令
+	for _, tt := range []struct{ rootCode, subCode, want int }{
+		{rootCode: 0, subCode: 65, want: 65},
+		{rootCode: 70, subCode: 0, want: 70},
+		{rootCode: 70, subCode: 65, want: 65},
+	} {
+		root := NewCmd("app", "", types.ExitOnError)
+		root.SetErr(&bytes.Buffer{})
+		root.SetValidationExitCode(tt.rootCode)
+		sub := NewCmd("sub", "", types.ExitOnError)
+		sub.SetValidationExitCode(tt.subCode)
+		sub.Int("port", "p", "port", 0)
+		sub.SetRun(func(c types.Command) error { return nil })
+		if err := root.AddSubCmds(sub); err != nil {
+			t.Fatalf("AddSubCmds() error = %v", err)
+		}
+
+		if code := root.execute(context.Background(), []string{"sub", "--port", "abc"}); code != tt.want {
+			t.Errorf("root=%d sub=%d: execute() code = %d, want %d", tt.rootCode, tt.subCode, code, tt.want)
+		}
+	}
+
+	// 错误收集模式下汇总的错误逐条输出中文信息
+	var stderr bytes.Buffer
+	collect := NewCmd("app", "", types.ContinueOnError)
+	collect.SetErr(&stderr)
+	collect.SetChinese(true)
+	collect.SetCollectErrors(true)
+	collect.Int("port", "p", "port", 0)
+	collect.SetRun(func(c types.Command) error { return nil })
+	collect.execute(context.Background(), []string{"--port", "abc", "--nope"})
+	if out := stderr.String(); !strings.Contains(out, "发生 2 个错误") || !strings.Contains(out, "未知标志") || !strings.Contains(out, "的值 \"abc\" 无效") {
+		t.Errorf("collected chinese stderr = %q", out)
+	}
+
+	// 运行函数返回 ctx.Err() 时按收到的信号映射退出码, 开启中文时输出中文信号信息
+	for _, sig := range []syscall.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		for _, chinese := range []bool{false, true} {
+			var stderr bytes.Buffer
+			cmd := NewCmd("app", "", types.ContinueOnError)
+			cmd.SetErr(&stderr)
+			cmd.SetChinese(chinese)
+			ctx, cancel := context.WithCancelCause(context.Background())
+			cmd.SetRun(func(c types.Command) error {
+				cancel(&types.SignalError{Signal: sig})
+				return c.Context().Err()
+			})
+			code := cmd.execute(ctx, nil)
+			cancel(nil)
+
+			want := "Error: received signal"
+			if chinese {
+				want = "错误: 收到信号"
+			}
+			if code != 128+int(sig) || !strings.HasPrefix(stderr.String(), want) {
+				t.Errorf("%v (chinese=%v): execute() code = %d, stderr = %q", sig, chinese, code, stderr.String())
+			}
+		}
+	}
+
+	// 执行结束后恢复原来的上下文, 不影响后续 ParseAndRoute 的错误处理
+	cmd := NewCmd("app", "", types.ContinueOnError)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetRun(func(c types.Command) error { return nil })
+	cmd.execute(context.Background(), nil)
+	if utils.Executing(cmd) {
+		t.Errorf("execute() should restore the command context")
+	}
+}
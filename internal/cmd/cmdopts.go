@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"io"
+
 	"gitee.com/MM-Q/qflag/internal/types"
 )
 
@@ -28,8 +30,13 @@ type CmdOpts struct {
 	Hidden             bool   // 是否隐藏命令, 不在帮助信息中显示
 	DisableFlagParsing bool   // 是否禁用标志解析, 所有参数都作为位置参数
 
-	// 运行函数
-	RunFunc func(types.Command) error // 命令执行函数
+	// 运行函数和生命周期钩子
+	RunFunc               func(types.Command) error // 命令执行函数
+	PreRunFunc            func(types.Command) error // 前置钩子, 在执行函数之前调用
+	PostRunFunc           func(types.Command) error // 后置钩子, 在执行函数之后调用 (执行函数失败时也会调用)
+	PersistentPreRunFunc  func(types.Command) error // 持久前置钩子, 在自身及所有子孙命令执行前调用
+	PersistentPostRunFunc func(types.Command) error // 持久后置钩子, 在自身及所有子孙命令执行后调用
+	Middlewares           []types.Middleware        // 运行中间件, 包装自身及所有子孙命令的执行函数
 
 	// 配置选项
 	Version           string // 版本号
@@ -39,6 +46,22 @@ type CmdOpts struct {
 	LogoText          string // Logo文本
 	Completion        bool   // 是否启用自动补全标志
 	DynamicCompletion bool   // 是否启用动态补全
+	Interspersed      bool   // 是否允许标志与位置参数交替出现
+	AllowAbbrev       bool   // 是否允许使用唯一前缀缩写长标志和子命令
+	ResponseFiles     bool   // 是否展开 @file 形式的响应文件
+	CollectErrors     bool   // 是否收集一次解析中的所有错误后汇总返回
+
+	// 配置文件
+	ConfigFile    string              // 配置文件路径
+	ConfigDecoder types.ConfigDecoder // 配置文件解码器, 为nil时按扩展名选择
+
+	// 输入输出, 为nil时继承父命令或使用标准输入输出
+	Out io.Writer // 标准输出
+	Err io.Writer // 标准错误输出
+	In  io.Reader // 标准输入
+
+	// 退出码
+	ValidationExitCode int // 值验证错误的退出码, 为0时继承父命令或使用 2
 
 	// 环境变量绑定
 	AutoBindEnv bool // 是否自动绑定所有标志的环境变量
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"sync"
 	"testing"
 
@@ -103,16 +105,34 @@ func TestCmd_ApplyOpts_AllFields(t *testing.T) {
 		t.Fatalf("Failed to add xml flag: %v", err)
 	}
 
+	var out, errOut bytes.Buffer
+	in := strings.NewReader("input")
 	opts := &CmdOpts{
 		Desc: "test command",
 		RunFunc: func(c types.Command) error {
 			return nil
 		},
-		Version:     "1.0.0",
-		UseChinese:  true,
-		EnvPrefix:   "TEST",
-		UsageSyntax: "test [options]",
-		LogoText:    "Test Logo",
+		PreRunFunc: func(c types.Command) error {
+			return nil
+		},
+		PersistentPostRunFunc: func(c types.Command) error {
+			return nil
+		},
+		Middlewares:        []types.Middleware{func(next types.RunFunc) types.RunFunc { return next }},
+		Version:            "1.0.0",
+		UseChinese:         true,
+		EnvPrefix:          "TEST",
+		UsageSyntax:        "test [options]",
+		LogoText:           "Test Logo",
+		Interspersed:       true,
+		AllowAbbrev:        true,
+		ResponseFiles:      true,
+		CollectErrors:      true,
+		ConfigFile:         "app.toml",
+		Out:                &out,
+		Err:                &errOut,
+		In:                 in,
+		ValidationExitCode: 65,
 		Examples: map[string]string{
 			"example1": "test --help",
 			"example2": "test --version",
@@ -159,6 +179,35 @@ func TestCmd_ApplyOpts_AllFields(t *testing.T) {
 		t.Errorf("Expected LogoText 'Test Logo', got '%s'", cmd.Config().LogoText)
 	}
 
+	if !cmd.Config().Interspersed {
+		t.Errorf("Expected Interspersed true, got false")
+	}
+
+	if !cmd.Config().AllowAbbrev {
+		t.Errorf("Expected AllowAbbrev true, got false")
+	}
+	if !cmd.Config().ResponseFiles {
+		t.Errorf("Expected ResponseFiles true, got false")
+	}
+	if !cmd.Config().CollectErrors {
+		t.Errorf("Expected CollectErrors true, got false")
+	}
+	if cmd.Config().ConfigFile != "app.toml" {
+		t.Errorf("Expected ConfigFile 'app.toml', got '%s'", cmd.Config().ConfigFile)
+	}
+	if cmd.Hook(types.PreRunHook) == nil || cmd.Hook(types.PersistentPostRunHook) == nil || cmd.Hook(types.PostRunHook) != nil {
+		t.Errorf("Expected lifecycle hooks to be applied")
+	}
+	if cmd.Config().ValidationExitCode != 65 {
+		t.Errorf("Expected ValidationExitCode 65, got %d", cmd.Config().ValidationExitCode)
+	}
+	if len(cmd.Middlewares()) != 1 {
+		t.Errorf("Expected 1 middleware, got %d", len(cmd.Middlewares()))
+	}
+	if cmd.Out() != &out || cmd.ErrOut() != &errOut || cmd.In() != in {
+		t.Errorf("Expected Out/Err/In to be applied")
+	}
+
 	if len(cmd.Config().Example) != 2 {
 		t.Errorf("Expected 2 examples, got %d", len(cmd.Config().Example))
 	}
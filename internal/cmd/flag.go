@@ -73,6 +73,28 @@ func (c *Cmd) Bool(longName, shortName, description string, default_ bool) *flag
 	return f
 }
 
+// Count 创建计数标志
+//
+// 参数:
+//   - longName: 长标志名 (如 --long-name)
+//   - shortName: 短标志名 (如 -s)
+//   - description: 标志的描述信息
+//   - default_: 标志的默认值
+//
+// 返回值:
+//   - *flag.CountFlag: 新创建的计数标志
+func (c *Cmd) Count(longName, shortName, description string, default_ int) *flag.CountFlag {
+	if err := utils.ValidateFlagName(c, longName, shortName); err != nil {
+		panic(err)
+	}
+
+	f := flag.NewCountFlag(longName, shortName, description, default_)
+	if err := c.flagRegistry.Register(f); err != nil {
+		panic(err)
+	}
+	return f
+}
+
 // Int64 创建64位整数标志
 //
 // 参数:
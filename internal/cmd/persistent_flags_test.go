@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"gitee.com/MM-Q/qflag/internal/flag"
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+/*
+本测试文件验证持久标志 (PersistentFlags) 的各种场景，包括：
+
+1. 继承解析验证：
+   - 验证持久标志可以在任意层级的子命令中使用
+   - 验证解析结果写入同一个标志实例
+   - 验证在子命令之前使用持久标志同样有效
+
+2. 遮蔽规则验证：
+   - 验证子命令的同名标志优先于继承的持久标志
+
+3. 帮助信息验证：
+   - 验证子命令帮助信息中的全局选项部分
+*/
+
+// newPersistentTree 创建带持久标志的三层命令树
+func newPersistentTree() (*Cmd, *Cmd, *Cmd, *flag.StringFlag) {
+	root := NewCmd("app", "", types.ContinueOnError)
+	config := root.String("config", "c", "配置文件", "app.yaml")
+	if err := root.MarkPersistent("config"); err != nil {
+		panic(err)
+	}
+
+	server := NewCmd("server", "s", types.ContinueOnError)
+	start := NewCmd("start", "", types.ContinueOnError)
+	start.SetRun(func(types.Command) error { return nil })
+
+	if err := server.AddSubCmds(start); err != nil {
+		panic(err)
+	}
+	if err := root.AddSubCmds(server); err != nil {
+		panic(err)
+	}
+
+	return root, server, start, config
+}
+
+// TestPersistentFlags_ParseAtAnyDepth 测试持久标志在任意层级解析
+func TestPersistentFlags_ParseAtAnyDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"根命令", []string{"--config", "x.yaml", "server", "start"}},
+		{"中间命令", []string{"server", "-c", "x.yaml", "start"}},
+		{"叶子命令", []string{"server", "start", "--config=x.yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, _, _, config := newPersistentTree()
+
+			if err := root.ParseAndRoute(tt.args); err != nil {
+				t.Fatalf("ParseAndRoute error: %v", err)
+			}
+			if config.Get() != "x.yaml" {
+				t.Errorf("config: expected 'x.yaml', got %q", config.Get())
+			}
+		})
+	}
+}
+
+// TestPersistentFlags_Lists 测试持久标志和继承标志列表
+func TestPersistentFlags_Lists(t *testing.T) {
+	root, server, start, _ := newPersistentTree()
+
+	if len(root.PersistentFlags()) != 1 {
+		t.Errorf("root persistent flags: expected 1, got %d", len(root.PersistentFlags()))
+	}
+	if len(root.InheritedFlags()) != 0 {
+		t.Errorf("root inherited flags: expected 0, got %d", len(root.InheritedFlags()))
+	}
+	if len(server.InheritedFlags()) != 1 || len(start.InheritedFlags()) != 1 {
+		t.Errorf("descendants should inherit 'config'")
+	}
+
+	if err := root.MarkPersistent("missing"); err == nil {
+		t.Error("expected error when marking unknown flag")
+	}
+}
+
+// TestPersistentFlags_Shadowed 测试子命令同名标志遮蔽持久标志
+func TestPersistentFlags_Shadowed(t *testing.T) {
+	root, server, _, config := newPersistentTree()
+	local := server.String("config", "", "服务配置", "server.yaml")
+
+	if len(server.InheritedFlags()) != 0 {
+		t.Errorf("shadowed flag should not be inherited")
+	}
+
+	if err := root.Parse([]string{"server", "--config", "local.yaml"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if local.Get() != "local.yaml" {
+		t.Errorf("local: expected 'local.yaml', got %q", local.Get())
+	}
+	if config.Get() != "app.yaml" {
+		t.Errorf("persistent: expected default, got %q", config.Get())
+	}
+}
+
+// TestPersistentFlags_Help 测试全局选项帮助信息
+func TestPersistentFlags_Help(t *testing.T) {
+	root, _, start, _ := newPersistentTree()
+
+	if !strings.Contains(start.Help(), "Global Options:") {
+		t.Errorf("subcommand help should contain global options section:\n%s", start.Help())
+	}
+	if strings.Contains(root.Help(), "Global Options:") {
+		t.Errorf("root help should not contain global options section")
+	}
+}
+
+// TestPersistentFlags_AddPersistentFlag 测试直接添加持久标志
+func TestPersistentFlags_AddPersistentFlag(t *testing.T) {
+	root, _, start, _ := newPersistentTree()
+	verbose := flag.NewBoolFlag("verbose", "v", "详细输出", false)
+	if err := root.AddPersistentFlag(verbose); err != nil {
+		t.Fatalf("AddPersistentFlag error: %v", err)
+	}
+
+	if err := root.Parse([]string{"server", "start", "-v"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !verbose.Get() {
+		t.Error("verbose should be set from leaf command")
+	}
+	if len(start.InheritedFlags()) != 2 {
+		t.Errorf("start inherited flags: expected 2, got %d", len(start.InheritedFlags()))
+	}
+}
@@ -77,11 +77,15 @@ func generateBashCompletion(buf *bytes.Buffer, params []FlagParam, rootCmdOpts [
 		})
 		flagParamsBuf.WriteString(flagParamItem)
 
-		// 如果参数类型为枚举, 则生成枚举选项
+		// 如果参数类型为枚举, 则生成枚举选项; 限定了扩展名的文件值复用该映射存储扩展名
+		options := ""
 		if param.ValueType == "enum" && len(param.EnumOptions) > 0 {
 			// 将枚举选项转换为字符串, 使用|分隔符与其他选项保持一致
-			options := strings.Join(param.EnumOptions, "|")
-
+			options = strings.Join(param.EnumOptions, "|")
+		} else if param.ValueType == "file" && len(param.Extensions) > 0 {
+			options = strings.Join(param.Extensions, "|")
+		}
+		if options != "" {
 			// 写入枚举选项
 			enumOptionItem := buildString(func(builder *strings.Builder) {
 				builder.WriteString(programName)
@@ -0,0 +1,215 @@
+// callback.go - 用户补全函数和值类型提示支持
+//
+// 该文件实现了动态补全中对用户补全函数的调用: 将光标之前已输入的参数
+// 尽量解析到命令中, 再根据补全位置调用标志或位置参数值容器上的补全函数;
+// 没有补全函数时按值容器声明的值类型输出补全指令
+
+package completion
+
+import (
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
+)
+
+// valueOutput 标志值或位置参数值的补全结果, 来自补全函数或值类型提示
+type valueOutput struct {
+	matches    []string                  // 按当前输入过滤后的候选值
+	descs      map[string]string         // 候选值描述
+	directive  types.CompletionDirective // 补全指令
+	extensions []string                  // 过滤文件使用的扩展名
+	kind       types.ValueKind           // 值容器声明的值类型
+}
+
+// partialParse 将已输入的参数尽量解析到上下文命令中
+//
+// 参数:
+//   - root: 根命令
+//   - cmd: 上下文路径对应的命令
+//   - tokens: 已输入的参数 (不包含程序名和当前输入)
+//
+// 返回值:
+//   - []string: 上下文命令的位置参数
+//
+// 功能说明:
+//   - 跳过子命令路径, 设置遇到的标志值, 收集位置参数
+//   - 位置参数通过 SetArgs 设置, 并绑定到已声明的位置参数值容器
+//   - 解析失败的值和未知标志会被忽略, 不影响补全
+func partialParse(root types.Command, cmd types.Command, tokens []string) []string {
+	// 1. 跳过子命令路径 (与 CalculateContext 的规则一致)
+	cur, i := root, 0
+	for ; cur != cmd && i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "--" || (strings.HasPrefix(token, "-") && strings.Contains(token, "=")) {
+			continue
+		}
+		sub, _ := utils.ResolveSubCmd(cur, token)
+		if sub == nil {
+			break
+		}
+		cur = sub
+	}
+	if cur != cmd {
+		return nil
+	}
+
+	// 2. 设置标志值, 收集位置参数
+	var positionals []string
+	afterDash := false
+	for ; i < len(tokens); i++ {
+		token := tokens[i]
+		if afterDash || token == "-" || !strings.HasPrefix(token, "-") {
+			positionals = append(positionals, token)
+			continue
+		}
+		if token == "--" {
+			afterDash = true
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(token, "=")
+		flag := findFlagByName(cmd, name)
+		switch {
+		case flag == nil:
+			// 未知标志, 忽略
+		case hasValue:
+			_ = flag.Set(value)
+		case flag.IsNegatable() && name == "--"+types.NegatePrefix+flag.LongName():
+			_ = flag.Set("false")
+		case flag.Type() == types.FlagTypeBool:
+			_ = flag.Set("true")
+		case !takesValue(flag):
+			// 计数标志和设置了隐式值的标志不取走下一个参数
+		case i+1 < len(tokens):
+			i++
+			_ = flag.Set(tokens[i])
+		}
+	}
+
+	// 3. 绑定位置参数
+	cmd.SetArgs(positionals)
+	for idx, spec := range cmd.ArgSpecs() {
+		if spec.Value == nil || idx >= len(positionals) {
+			break
+		}
+		if spec.Variadic {
+			if setter, ok := spec.Value.(types.ItemSetter); ok {
+				_ = setter.SetItems(positionals[idx:])
+			}
+			break
+		}
+		_ = spec.Value.Set(positionals[idx])
+	}
+
+	return positionals
+}
+
+// takesValue 判断标志是否会取走下一个参数作为值
+//
+// 参数:
+//   - flag: 标志
+//
+// 返回值:
+//   - bool: 布尔标志、计数标志和设置了隐式值的标志返回false
+func takesValue(flag types.Flag) bool {
+	if flag.Type() == types.FlagTypeBool || flag.Type() == types.FlagTypeCount {
+		return false
+	}
+	_, ok := flag.ImplicitValue()
+	return !ok
+}
+
+// argValue 获取下一个位置参数的值容器
+//
+// 参数:
+//   - cmd: 上下文命令
+//   - index: 下一个位置参数的索引
+//
+// 返回值:
+//   - types.Flag: 值容器, 未声明对应的位置参数时返回nil
+func argValue(cmd types.Command, index int) types.Flag {
+	specs := cmd.ArgSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// 超出声明个数时只有可变参数继续接收
+	if index >= len(specs) {
+		if !specs[len(specs)-1].Variadic {
+			return nil
+		}
+		index = len(specs) - 1
+	}
+
+	return specs[index].Value
+}
+
+// completeValue 补全标志值或位置参数值
+//
+// 参数:
+//   - value: 标志或位置参数的值容器
+//   - cmd: 上下文命令
+//   - cur: 当前输入
+//
+// 返回值:
+//   - *valueOutput: 补全结果, 既没有补全函数也没有声明值类型时返回nil
+//
+// 功能说明:
+//   - 设置了补全函数时调用补全函数
+//   - 否则按 SetValueHint 声明的值类型输出补全指令, 候选项为空
+func completeValue(value types.Flag, cmd types.Command, cur string) *valueOutput {
+	if value == nil {
+		return nil
+	}
+
+	hint := value.ValueHint()
+	if fn := value.CompletionFunc(); fn != nil {
+		out := runCompletionFunc(fn, cmd, cur)
+		out.kind = hint.Kind
+		return out
+	}
+
+	if hint.Kind == types.ValueKindAuto {
+		return nil
+	}
+	return &valueOutput{
+		descs:      map[string]string{},
+		directive:  hint.Directive(),
+		extensions: hint.Extensions,
+		kind:       hint.Kind,
+	}
+}
+
+// runCompletionFunc 调用补全函数并按当前输入过滤候选项
+//
+// 参数:
+//   - fn: 补全函数
+//   - cmd: 上下文命令
+//   - cur: 当前输入
+//
+// 返回值:
+//   - *valueOutput: 过滤后的候选值、描述和补全指令
+func runCompletionFunc(fn types.CompletionFunc, cmd types.Command, cur string) *valueOutput {
+	result := fn(cmd, cur)
+
+	out := &valueOutput{
+		descs:      make(map[string]string),
+		directive:  result.Directive,
+		extensions: result.Extensions,
+	}
+
+	values := make([]string, 0, len(result.Candidates))
+	for _, c := range result.Candidates {
+		if c.Value == "" {
+			continue
+		}
+		values = append(values, c.Value)
+		if c.Desc != "" {
+			out.descs[c.Value] = strings.Join(strings.Fields(c.Desc), " ")
+		}
+	}
+	out.matches = fuzzyMatch(values, cur)
+
+	return out
+}
@@ -51,7 +51,7 @@ func HandleCandidates(root types.Command, args []string) error {
 	candidates = append(candidates, getBuiltinFlagNames(cmd, context)...)
 
 	// 输出（空格分隔）
-	fmt.Println(strings.Join(candidates, " "))
+	fmt.Fprintln(root.Out(), strings.Join(candidates, " "))
 
 	return nil
 }
@@ -22,8 +22,10 @@ type FlagParam struct {
 	CommandPath string   // 命令路径, 如 "/cmd/subcmd"
 	Name        string   // 标志名称(保留原始大小写)
 	Type        string   // 参数需求类型: "required"|"optional"|"none"
-	ValueType   string   // 参数值类型: "path"|"string"|"number"|"enum"|"bool"等
+	ValueType   string   // 参数值类型: "string"|"enum"|"bool"|"count", 声明了值类型时为 "file"|"dir"|"hostname"|"none"
 	EnumOptions []string // 枚举类型的可选值列表
+	Extensions  []string // "file" 值类型允许的扩展名列表, 如 ".yaml"
+	Desc        string   // 标志描述
 }
 
 //go:embed templates/bash.tmpl
@@ -38,24 +40,39 @@ var bashDynamicTemplate string
 //go:embed templates/pwsh_dynamic.tmpl
 var pwshDynamicTemplate string
 
+//go:embed templates/zsh.tmpl
+var zshTemplate string
+
+//go:embed templates/zsh_dynamic.tmpl
+var zshDynamicTemplate string
+
+//go:embed templates/fish.tmpl
+var fishTemplate string
+
+//go:embed templates/fish_dynamic.tmpl
+var fishDynamicTemplate string
+
 // GenAndPrint 生成并打印补全脚本
 //
 // 参数:
 //   - cmd: 要生成补全脚本的命令
-//   - shellType: Shell类型 (bash, pwsh, powershell)
+//   - shellType: Shell类型 (bash, zsh, fish, pwsh, powershell)
+//
+// 注意事项:
+//   - 脚本写入命令的标准输出, 错误信息写入命令的标准错误输出
 func GenAndPrint(cmd types.Command, shellType string) {
 	st, err := Generate(cmd, shellType)
 	if err != nil {
-		fmt.Printf("Error generating completion script: %v\n", err)
+		fmt.Fprintf(cmd.ErrOut(), "Error generating completion script: %v\n", err)
 	}
-	fmt.Println(st)
+	fmt.Fprintln(cmd.Out(), st)
 }
 
 // Generate 生成补全脚本
 //
 // 参数:
 //   - cmd: 要生成补全脚本的命令
-//   - shellType: Shell类型 (bash, pwsh, powershell)
+//   - shellType: Shell类型 (bash, zsh, fish, pwsh, powershell)
 //
 // 返回值:
 //   - string: 生成的补全脚本
@@ -74,7 +91,7 @@ func Generate(cmd types.Command, shellType string) (string, error) {
 //
 // 参数:
 //   - cmd: 要生成补全脚本的命令
-//   - shellType: Shell类型 (bash, pwsh, powershell)
+//   - shellType: Shell类型 (bash, zsh, fish, pwsh, powershell)
 //
 // 返回值:
 //   - string: 生成的补全脚本
@@ -103,6 +120,12 @@ func GenerateStatic(cmd types.Command, shellType string) (string, error) {
 	case types.BashShell: // Bash特定处理
 		generateBashCompletion(&buf, params, rootCmdOpts, cmdTreeEntries.String(), programName)
 
+	case types.ZshShell: // Zsh特定处理
+		generateZshCompletion(&buf, params, cmd, cmdTreeEntries.String(), programName)
+
+	case types.FishShell: // Fish特定处理
+		generateFishCompletion(&buf, params, cmd, cmdTreeEntries.String(), programName)
+
 	case types.PwshShell, types.PowershellShell: // PowerShell特定处理
 		generatePwshCompletion(&buf, params, rootCmdOpts, cmdTreeEntries.String(), programName)
 
@@ -118,7 +141,7 @@ func GenerateStatic(cmd types.Command, shellType string) (string, error) {
 //
 // 参数:
 //   - cmd: 要生成补全脚本的命令
-//   - shellType: Shell类型 (bash, pwsh, powershell)
+//   - shellType: Shell类型 (bash, zsh, fish, pwsh, powershell)
 //
 // 返回值:
 //   - string: 生成的补全脚本
@@ -132,6 +155,12 @@ func GenerateDynamic(cmd types.Command, shellType string) (string, error) {
 	case types.BashShell: // Bash特定处理
 		return generateBashDynamicCompletion(programName)
 
+	case types.ZshShell: // Zsh特定处理
+		return generateZshDynamicCompletion(programName)
+
+	case types.FishShell: // Fish特定处理
+		return generateFishDynamicCompletion(programName)
+
 	case types.PwshShell, types.PowershellShell: // PowerShell特定处理
 		return generatePwshDynamicCompletion(programName)
 
@@ -200,6 +229,13 @@ func traverseCommandTree(buf *bytes.Buffer, rootPath string, cmds []types.Comman
 			programName := filepath.Base(os.Args[0])
 			generateBashCommandTreeEntry(buf, fullPath, opts, programName)
 
+		case types.ZshShell: // Zsh特定处理, 条目带有描述
+			generateZshCommandTreeEntry(buf, fullPath, cur.cmd)
+
+		case types.FishShell: // Fish特定处理, 登记上下文并补全子命令
+			programName := filepath.Base(os.Args[0])
+			generateFishCommandTreeEntry(buf, fullPath, cur.cmd, programName)
+
 		case types.PwshShell, types.PowershellShell: // Powershell特定处理
 			generatePwshCommandTreeEntry(buf, fullPath, opts)
 		}
@@ -279,14 +315,23 @@ func collectFlagParameters(cmd types.Command) []FlagParam {
 		param := FlagParam{
 			CommandPath: cur.path,
 			Name:        prefix + name,
-			Type:        getParamTypeByFlagType(ft),
+			Type:        getParamType(flag),
 			ValueType:   getValueTypeByFlagType(ft),
+			Desc:        flag.Desc(),
 		}
 
 		// 如果是枚举标志, 则获取枚举选项
 		if ft == types.FlagTypeEnum {
 			param.EnumOptions = flag.EnumValues()
 		}
+
+		// 非枚举的值使用声明的值类型 (文件、目录、主机名或不补全)
+		if param.ValueType == "string" {
+			if hint := flag.ValueHint(); hint.Kind != types.ValueKindAuto {
+				param.ValueType = hint.Kind.String()
+				param.Extensions = hint.Extensions
+			}
+		}
 		params = append(params, param)
 	}
 
@@ -295,8 +340,9 @@ func collectFlagParameters(cmd types.Command) []FlagParam {
 		cur := q[0] // 获取当前节点
 		q = q[1:]   // 移除当前节点
 
-		// 遍历当前命令的所有标志
-		for _, flag := range cur.cmd.Flags() {
+		// 遍历当前命令的所有标志 (包括继承的持久标志)
+		flags := append(cur.cmd.Flags(), cur.cmd.InheritedFlags()...)
+		for _, flag := range flags {
 			// 如果短标志不为空, 则添加短标志
 			if flag.ShortName() != "" {
 				add("-", flag.ShortName(), cur, flag)
@@ -306,6 +352,11 @@ func collectFlagParameters(cmd types.Command) []FlagParam {
 			if flag.LongName() != "" {
 				add("--", flag.LongName(), cur, flag)
 			}
+
+			// 如果支持取反, 则添加 --no- 形式
+			if flag.IsNegatable() {
+				add("--", types.NegatePrefix+flag.LongName(), cur, flag)
+			}
 		}
 
 		// 子命令入队
@@ -336,8 +387,8 @@ func collectCompletionOptions(cmd types.Command) []string {
 		return nil
 	}
 
-	// 获取所有标志数量(长标志+短标志)
-	flags := cmd.Flags()
+	// 获取所有标志数量(长标志+短标志), 包括继承的持久标志
+	flags := append(cmd.Flags(), cmd.InheritedFlags()...)
 	flagCnt := len(flags)
 
 	// 计算总容量 (标志数量+子命令数量×每项名称数)
@@ -364,6 +415,10 @@ func collectCompletionOptions(cmd types.Command) []string {
 			add("--" + flag.LongName())
 		}
 
+		if flag.IsNegatable() {
+			add("--" + types.NegatePrefix + flag.LongName())
+		}
+
 		if flag.ShortName() != "" {
 			add("-" + flag.ShortName())
 		}
@@ -411,6 +466,22 @@ func buildString(fn func(*strings.Builder)) string {
 	return builder.String()
 }
 
+// scriptIdent 将程序名转换为合法的Shell函数名和变量名片段
+//
+// 参数:
+//   - programName: 程序名称
+//
+// 返回值:
+//   - string: 只包含字母、数字和下划线的标识符
+func scriptIdent(programName string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, programName)
+}
+
 // getValueTypeByFlagType 根据标志类型获取值类型
 //
 // 参数:
@@ -422,6 +493,8 @@ func getValueTypeByFlagType(flagType types.FlagType) string {
 	switch flagType {
 	case types.FlagTypeBool:
 		return "bool"
+	case types.FlagTypeCount:
+		return "count"
 	case types.FlagTypeEnum:
 		return "enum"
 	default:
@@ -429,6 +502,20 @@ func getValueTypeByFlagType(flagType types.FlagType) string {
 	}
 }
 
+// getParamType 获取标志的参数需求类型
+//
+// 参数:
+//   - flag - 标志
+//
+// 返回值:
+//   - string: 参数需求类型, 设置了隐式值的标志为 "optional" (值不会取自下一个参数)
+func getParamType(flag types.Flag) string {
+	if _, ok := flag.ImplicitValue(); ok {
+		return "optional"
+	}
+	return getParamTypeByFlagType(flag.Type())
+}
+
 // getParamTypeByFlagType 根据标志类型获取参数需求类型
 //
 // 参数:
@@ -437,7 +524,7 @@ func getValueTypeByFlagType(flagType types.FlagType) string {
 // 返回值:
 //   - string: 参数需求类型
 func getParamTypeByFlagType(flagType types.FlagType) string {
-	if flagType == types.FlagTypeBool {
+	if flagType == types.FlagTypeBool || flagType == types.FlagTypeCount {
 		return "none"
 	}
 	return "required"
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
 )
 
 // ContextResult 上下文计算结果
@@ -60,7 +61,7 @@ func HandleContext(root types.Command, args []string) error {
 	result := CalculateContext(root, tokens, cursorPos)
 
 	// 输出结果（只输出上下文路径）
-	fmt.Println(result.Context)
+	fmt.Fprintln(root.Out(), result.Context)
 
 	return nil
 }
@@ -71,7 +72,7 @@ func HandleContext(root types.Command, args []string) error {
 // 算法逻辑:
 //  1. 从索引 1 开始遍历 tokens（跳过程序名）
 //  2. 遇到以 "-" 开头的 token，标记为标志上下文并停止
-//  3. 在 cmdRegistry 中查找子命令
+//  3. 在 cmdRegistry 中查找子命令, 开启缩写模式时唯一前缀也会被解析
 //  4. 找到则更新上下文，继续遍历
 //  5. 未找到则停止遍历，保持当前上下文
 //
@@ -119,18 +120,18 @@ func CalculateContext(root types.Command, tokens []string, cursorPos int) *Conte
 			break
 		}
 
-		// 规则 2: 在注册表中查找子命令
-		subCmd, found := currentCmd.GetSubCmd(token)
-		if !found {
-			// 不是有效的子命令，停止遍历
+		// 规则 2: 在注册表中查找子命令 (开启缩写模式时与解析器一样支持唯一前缀)
+		subCmd, _ := utils.ResolveSubCmd(currentCmd, token)
+		if subCmd == nil {
+			// 不是有效的子命令 (或前缀有歧义)，停止遍历
 			break
 		}
 
-		// 规则 3: 更新上下文
+		// 规则 3: 更新上下文 (使用解析后的子命令名称)
 		result.ParentContext = result.Context
-		result.Context += token + "/"
+		result.Context += subCmd.Name() + "/"
 		result.Depth++
-		result.CurrentCmd = token
+		result.CurrentCmd = subCmd.Name()
 		result.CurrentDesc = subCmd.Desc()
 		currentCmd = subCmd
 	}
@@ -168,7 +169,7 @@ func getSubCommandNames(cmd types.Command) []string {
 	return names
 }
 
-// getFlagNames 获取标志名称列表（包括长短名称）
+// getFlagNames 获取标志名称列表（包括长短名称和继承的持久标志）
 //
 // 参数:
 //   - cmd: 命令实例
@@ -176,7 +177,7 @@ func getSubCommandNames(cmd types.Command) []string {
 // 返回值:
 //   - []string: 标志名称列表（长名称带 -- 前缀，短名称带 - 前缀）
 func getFlagNames(cmd types.Command) []string {
-	flags := cmd.Flags()
+	flags := append(cmd.Flags(), cmd.InheritedFlags()...)
 	names := make([]string, 0, len(flags)*2)
 
 	for _, flag := range flags {
@@ -185,6 +186,11 @@ func getFlagNames(cmd types.Command) []string {
 			names = append(names, "--"+flag.LongName())
 		}
 
+		// 添加取反形式（仅支持取反的布尔标志）
+		if flag.IsNegatable() {
+			names = append(names, "--"+types.NegatePrefix+flag.LongName())
+		}
+
 		// 添加短名称（带 - 前缀，如果有）
 		if flag.ShortName() != "" {
 			names = append(names, "-"+flag.ShortName())
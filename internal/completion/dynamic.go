@@ -4,6 +4,8 @@ package completion
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"gitee.com/MM-Q/go-kit/fuzzy"
@@ -19,10 +21,13 @@ import (
 //
 // 返回值:
 //   - error: 处理错误
+//
+// 注意事项:
+//   - 所有指令的结果都写入根命令的标准输出 (root.Out())
 func HandleDynamicComplete(root types.Command, instruction string, params []string) error {
 	switch instruction {
 	case types.InstructionFuzzy:
-		return handleFuzzy(params)
+		return handleFuzzy(root.Out(), params)
 
 	case types.InstructionContext:
 		return HandleContext(root, params)
@@ -36,6 +41,9 @@ func HandleDynamicComplete(root types.Command, instruction string, params []stri
 	case types.InstructionAll:
 		return handleAll(root, params)
 
+	case types.InstructionJSON:
+		return handleJSON(root, params)
+
 	default:
 		return fmt.Errorf("unknown instruction: %s", instruction)
 	}
@@ -44,13 +52,14 @@ func HandleDynamicComplete(root types.Command, instruction string, params []stri
 // handleFuzzy 处理 fuzzy 指令
 //
 // 参数:
+//   - w: 输出目标
 //   - args: 参数列表，第一个是模式，后面是候选列表
 //
 // 返回值:
 //   - error: 处理错误
 //
 // 输出格式: 每行一个匹配结果（按匹配质量降序）
-func handleFuzzy(args []string) error {
+func handleFuzzy(w io.Writer, args []string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("usage: __complete fuzzy <pattern> <candidates...>")
 	}
@@ -63,12 +72,26 @@ func handleFuzzy(args []string) error {
 
 	// 输出匹配结果（只输出匹配的字符串）
 	for _, match := range matches {
-		fmt.Println(match.Str)
+		fmt.Fprintln(w, match.Str)
 	}
 
 	return nil
 }
 
+// allResult all 和 json 指令的补全结果
+type allResult struct {
+	cmd         types.Command // 上下文命令, 可能为nil
+	context     string        // 上下文路径
+	cur         string        // 当前输入
+	prev        string        // 前一个词
+	candidates  []string      // 上下文中的全部候选项
+	enumValues  []string      // 枚举标志的全部枚举值
+	matches     []string      // 匹配结果
+	isFlagValue bool          // 是否在补全标志值
+	valueFlag   string        // 正在补全其值的标志, 布尔、计数和未知标志为空
+	valueOut    *valueOutput  // 补全函数或值类型提示的结果, 可能为nil
+}
+
 // handleAll 处理 all 指令，一次性返回所有补全信息
 //
 // 参数:
@@ -90,9 +113,60 @@ func handleFuzzy(args []string) error {
 //	ENUM:<枚举值列表>
 //	MATCHES:<匹配结果>
 //	IS_FLAG:<true|false>
+//	DESC:<候选值>\t<描述>     (每个带描述的匹配结果一行)
+//	DIRECTIVE:<补全指令>      (仅在调用了补全函数或声明了值类型时输出)
+//	EXTS:<扩展名列表>         (仅在调用了补全函数或声明了值类型时输出)
+//
+// 标志值或位置参数值设置了补全函数时, 先将已输入的参数尽量解析到命令中,
+// 再调用补全函数获取候选项; 没有补全函数但声明了值类型 (文件、目录、主机名等) 时,
+// 输出对应的补全指令。没有匹配结果时, 脚本按 DIRECTIVE 决定如何补全。
+//
+// 注意事项:
+//   - 该格式供已安装的旧版补全脚本使用, 保持不变
+//   - 环境变量 QFLAG_COMPLETE_FORMAT=json 时改为输出 JSON 格式, 与 json 指令相同
 func handleAll(root types.Command, args []string) error {
+	res, err := completeAll(root, args)
+	if err != nil {
+		return err
+	}
+
+	w := root.Out()
+	if os.Getenv(types.CompleteFormatEnv) == types.CompleteFormatJSON {
+		return writeJSONResponse(w, res)
+	}
+
+	fmt.Fprintf(w, "CONTEXT:%s\n", res.context)
+	fmt.Fprintf(w, "CUR:%s\n", res.cur)
+	fmt.Fprintf(w, "PREV:%s\n", res.prev)
+	fmt.Fprintf(w, "CANDIDATES:%s\n", strings.Join(res.candidates, " "))
+	fmt.Fprintf(w, "ENUM:%s\n", strings.Join(res.enumValues, " "))
+	fmt.Fprintf(w, "MATCHES:%s\n", strings.Join(res.matches, " "))
+	fmt.Fprintf(w, "IS_FLAG:%v\n", res.isFlagValue && len(res.enumValues) > 0)
+	if res.valueOut != nil {
+		for _, m := range res.valueOut.matches {
+			if desc, ok := res.valueOut.descs[m]; ok {
+				fmt.Fprintf(w, "DESC:%s\t%s\n", m, desc)
+			}
+		}
+		fmt.Fprintf(w, "DIRECTIVE:%d\n", res.valueOut.directive)
+		fmt.Fprintf(w, "EXTS:%s\n", strings.Join(res.valueOut.extensions, " "))
+	}
+
+	return nil
+}
+
+// completeAll 计算 all 和 json 指令的补全结果
+//
+// 参数:
+//   - root: 根命令实例
+//   - args: [cur, prev, cmd_args...], 与 handleAll 相同
+//
+// 返回值:
+//   - *allResult: 补全结果
+//   - error: 参数不足时返回错误
+func completeAll(root types.Command, args []string) (*allResult, error) {
 	if len(args) < 2 {
-		return fmt.Errorf("usage: __complete all <cur> <prev> [cmd_args...]")
+		return nil, fmt.Errorf("usage: __complete all|json <cur> <prev> [cmd_args...]")
 	}
 
 	// 解析参数
@@ -122,48 +196,111 @@ func handleAll(root types.Command, args []string) error {
 	// 3. 执行补全逻辑
 	var matchStrings []string
 	var enumValues []string
+	var valueOut *valueOutput
+
+	// 将已输入的参数解析到上下文命令中, 供补全函数使用
+	cmd := findCommandByContext(root, context)
+	var positionals []string
+	if cmd != nil {
+		positionals = partialParse(root, cmd, cmdArgs)
+	}
+
+	// 普通候选项补全, 位置参数设置了补全函数或声明了值类型时按位置参数值补全
+	completeCandidates := func() {
+		valueOut = completeValue(positionalValue(cmd, cur, len(positionals)), cmd, cur)
+		if valueOut == nil {
+			matchStrings = fuzzyMatch(candidates, cur)
+			return
+		}
+
+		matchStrings = valueOut.matches
+
+		// 第一个位置参数也可能是子命令
+		if len(positionals) == 0 {
+			matchStrings = append(matchStrings, fuzzyMatch(getSubCommandNames(cmd), cur)...)
+		}
+	}
 
 	// 3. 判断补全类型并执行相应逻辑
 	isFlagValueCompletion := isFlagValueContext(cur, prev)
+	var valueFlag string
 
 	if isFlagValueCompletion {
 		// ========== 标志值补全 ==========
 		flagType, found := getFlagType(root, context, prev)
 
-		if !found {
+		// 设置了隐式值的标志不会取走下一个参数, 按普通候选项补全
+		if found && hasImplicitValue(root, context, prev) {
+			flagType = types.FlagTypeBool
+		}
+
+		// 设置了补全函数的标志优先调用补全函数
+		var flag types.Flag
+		if cmd != nil {
+			flag = findFlagByName(cmd, prev)
+		}
+
+		if flag != nil && takesValue(flag) && flag.CompletionFunc() != nil {
+			valueFlag = prev
+			valueOut = completeValue(flag, cmd, cur)
+			matchStrings = valueOut.matches
+		} else if !found {
 			// 标志不存在，按普通候选项补全
-			matchStrings = fuzzyMatch(candidates, cur)
+			completeCandidates()
 		} else {
 			switch flagType {
-			case types.FlagTypeBool:
-				// 布尔标志：不需要值，补全其他标志/子命令
-				matchStrings = fuzzyMatch(candidates, cur)
+			case types.FlagTypeBool, types.FlagTypeCount:
+				// 布尔标志和计数标志：不需要值，补全其他标志/子命令
+				completeCandidates()
 
 			case types.FlagTypeEnum:
 				// 枚举标志：获取枚举值并模糊匹配
+				valueFlag = prev
 				enumValues, _ = GetEnumValues(root, context, prev)
 				matchStrings = fuzzyMatch(enumValues, cur)
 
 			default:
 				// 其他类型（String/Int/Duration/Size等）：需要值
-				// matchStrings 保持为空，由 Shell 回退到路径补全
+				// matchStrings 保持为空, 声明了值类型时输出补全指令, 否则由 Shell 回退到路径补全
+				valueFlag = prev
+				if flag != nil {
+					valueOut = completeValue(flag, cmd, cur)
+				}
 			}
 		}
 	} else {
 		// ========== 普通候选项补全 ==========
-		matchStrings = fuzzyMatch(candidates, cur)
+		completeCandidates()
 	}
 
-	// 5. 输出结果（带前缀的多行格式）
-	fmt.Printf("CONTEXT:%s\n", context)
-	fmt.Printf("CUR:%s\n", cur)
-	fmt.Printf("PREV:%s\n", prev)
-	fmt.Printf("CANDIDATES:%s\n", strings.Join(candidates, " "))
-	fmt.Printf("ENUM:%s\n", strings.Join(enumValues, " "))
-	fmt.Printf("MATCHES:%s\n", strings.Join(matchStrings, " "))
-	fmt.Printf("IS_FLAG:%v\n", isFlagValueCompletion && len(enumValues) > 0)
+	return &allResult{
+		cmd:         cmd,
+		context:     context,
+		cur:         cur,
+		prev:        prev,
+		candidates:  candidates,
+		enumValues:  enumValues,
+		matches:     matchStrings,
+		isFlagValue: isFlagValueCompletion,
+		valueFlag:   valueFlag,
+		valueOut:    valueOut,
+	}, nil
+}
 
-	return nil
+// positionalValue 获取当前位置参数的值容器
+//
+// 参数:
+//   - cmd: 上下文命令, 可以为nil
+//   - cur: 当前输入
+//   - index: 当前位置参数的索引
+//
+// 返回值:
+//   - types.Flag: 值容器, 正在输入标志或未声明对应的位置参数时返回nil
+func positionalValue(cmd types.Command, cur string, index int) types.Flag {
+	if cmd == nil || strings.HasPrefix(cur, "-") {
+		return nil
+	}
+	return argValue(cmd, index)
 }
 
 // getFlagType 获取指定上下文中标志的类型
@@ -197,6 +334,30 @@ func getFlagType(root types.Command, context string, flagName string) (types.Fla
 	return getBuiltinFlagType(flagName, context, cmd)
 }
 
+// hasImplicitValue 检查标志是否设置了隐式值
+//
+// 参数:
+//   - root: 根命令
+//   - context: 上下文路径
+//   - flagName: 标志名称
+//
+// 返回值:
+//   - bool: 设置了隐式值时返回true
+func hasImplicitValue(root types.Command, context string, flagName string) bool {
+	cmd := findCommandByContext(root, context)
+	if cmd == nil {
+		return false
+	}
+
+	flag := findFlagByName(cmd, flagName)
+	if flag == nil {
+		return false
+	}
+
+	_, ok := flag.ImplicitValue()
+	return ok
+}
+
 // getBuiltinFlagType 根据标志名称识别内置标志的类型
 //
 // 参数:
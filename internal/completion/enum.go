@@ -55,7 +55,7 @@ func HandleEnum(root types.Command, args []string) error {
 	}
 
 	// 输出 (空格分隔)
-	fmt.Println(strings.Join(enumValues, " "))
+	fmt.Fprintln(root.Out(), strings.Join(enumValues, " "))
 
 	return nil
 }
@@ -68,7 +68,7 @@ func HandleEnum(root types.Command, args []string) error {
 //   - flagName: 标志名称
 //
 // 返回值:
-//   - []string: 枚举值列表
+//   - []string: 枚举值列表, 按声明顺序排列
 //   - error: 处理错误
 func GetEnumValues(root types.Command, context string, flagName string) ([]string, error) {
 	// 根据上下文查找命令
@@ -101,12 +101,18 @@ func findFlagByName(cmd types.Command, flagName string) types.Flag {
 	// 移除可能的 "=" 后缀
 	flagName = strings.TrimSuffix(flagName, "=")
 
-	for _, flag := range cmd.Flags() {
+	// 命令自身的标志优先, 其次是继承的持久标志
+	flags := append(cmd.Flags(), cmd.InheritedFlags()...)
+	for _, flag := range flags {
 		// 匹配长名称 (flagName 带 "--" 前缀, flag.LongName() 不带)
 		if strings.HasPrefix(flagName, "--") {
 			if flag.LongName() == flagName[2:] { // 去掉 "--" 前缀再比较
 				return flag
 			}
+			// 匹配取反形式 --no-name
+			if flag.IsNegatable() && types.NegatePrefix+flag.LongName() == flagName[2:] {
+				return flag
+			}
 		}
 		// 匹配短名称 (flagName 带 "-" 前缀, flag.ShortName() 不带)
 		if strings.HasPrefix(flagName, "-") && flag.ShortName() != "" {
@@ -0,0 +1,220 @@
+// Package completion Fish 自动补全实现
+// 本文件实现了Fish环境下的命令行自动补全功能,
+// 生成由 complete 命令组成的Fish补全脚本, 标志和子命令的描述会显示在补全菜单中。
+package completion
+
+import (
+	"bytes"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// fishQuote 将字符串转换为Fish单引号字符串
+//
+// 参数:
+//   - s: 原始字符串
+//
+// 返回值:
+//   - string: 单引号包裹的字符串, 内部的反斜杠和单引号使用反斜杠转义
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "'", `\'`)
+	return "'" + s + "'"
+}
+
+// fishEscapeWord 转义Fish中的单个单词, 使其在 complete -a 的候选列表中保持为一个候选项
+//
+// 参数:
+//   - s: 原始单词
+//
+// 返回值:
+//   - string: 特殊字符前添加了反斜杠的单词
+func fishEscapeWord(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(" \t\n\\'\"$*?~#()[]{}<>&;|", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fishDesc 规范化补全描述, 将换行和连续空白合并为单个空格
+//
+// 参数:
+//   - desc: 原始描述
+//
+// 返回值:
+//   - string: 单行描述
+func fishDesc(desc string) string {
+	return strings.Join(strings.Fields(desc), " ")
+}
+
+// fishCondition 生成判断当前命令上下文的 complete -n 条件
+//
+// 参数:
+//   - programName: 程序名称
+//   - cmdPath: 命令路径, 如 "/server/"
+//
+// 返回值:
+//   - string: 已转义的条件参数
+func fishCondition(programName string, cmdPath string) string {
+	return fishQuote("__" + scriptIdent(programName) + "_using_context " + fishQuote(cmdPath))
+}
+
+// writeFishComplete 写入一条 complete 命令
+//
+// 参数:
+//   - buf: 输出缓冲区
+//   - programName: 程序名称
+//   - cmdPath: 命令路径
+//   - spec: 选项或候选部分, 如 "-l output -r -F"
+//   - desc: 描述, 为空时不输出 -d
+func writeFishComplete(buf *bytes.Buffer, programName string, cmdPath string, spec string, desc string) {
+	buf.WriteString("complete -c ")
+	buf.WriteString(programName)
+	buf.WriteString(" -n ")
+	buf.WriteString(fishCondition(programName, cmdPath))
+	buf.WriteByte(' ')
+	buf.WriteString(spec)
+	if desc = fishDesc(desc); desc != "" {
+		buf.WriteString(" -d ")
+		buf.WriteString(fishQuote(desc))
+	}
+	buf.WriteByte('\n')
+}
+
+// generateFishCommandTreeEntry 生成Fish命令树条目
+//
+// 参数:
+//   - cmdTreeEntries: 命令树条目缓冲区
+//   - cmdPath: 命令路径
+//   - cmd: 命令路径对应的命令
+//   - programName: 程序名称
+//
+// 功能说明:
+//   - 将命令路径登记为已知上下文, 并为其子命令生成补全 (带描述)
+//   - 子命令条目使用 -f, 在有子命令的上下文中不补全文件
+func generateFishCommandTreeEntry(cmdTreeEntries *bytes.Buffer, cmdPath string, cmd types.Command, programName string) {
+	cmdTreeEntries.WriteString("set -a __" + scriptIdent(programName) + "_contexts " + fishQuote(cmdPath) + "\n")
+
+	if cmd == nil {
+		return
+	}
+	for _, sub := range cmd.SubCmds() {
+		if sub == nil {
+			continue
+		}
+		for _, name := range []string{sub.LongName(), sub.ShortName()} {
+			if name != "" {
+				writeFishComplete(cmdTreeEntries, programName, cmdPath, "-f -a "+fishQuote(fishEscapeWord(name)), sub.Desc())
+			}
+		}
+	}
+}
+
+// fishFlagSpec 根据标志参数生成 complete 的选项部分
+//
+// 参数:
+//   - param: 标志参数
+//
+// 返回值:
+//   - string: 选项部分, 如 "-l output -r -F"
+func fishFlagSpec(param FlagParam) string {
+	var spec string
+	switch {
+	case strings.HasPrefix(param.Name, "--"):
+		spec = "-l " + fishEscapeWord(strings.TrimPrefix(param.Name, "--"))
+	case len([]rune(param.Name)) == 2: // 单字符短选项
+		spec = "-s " + fishEscapeWord(strings.TrimPrefix(param.Name, "-"))
+	default: // 多字符短选项
+		spec = "-o " + fishEscapeWord(strings.TrimPrefix(param.Name, "-"))
+	}
+
+	switch {
+	case param.ValueType == "enum" && len(param.EnumOptions) > 0:
+		words := make([]string, 0, len(param.EnumOptions))
+		for _, opt := range param.EnumOptions {
+			words = append(words, fishEscapeWord(opt))
+		}
+		if param.Type == "required" {
+			spec += " -x"
+		} else {
+			spec += " -f"
+		}
+		spec += " -a " + fishQuote(strings.Join(words, " "))
+
+	case param.Type != "required": // 可选值和无值的标志不补全值
+
+	case param.ValueType == "none":
+		spec += " -x"
+
+	case param.ValueType == "dir":
+		spec += " -x -a " + fishQuote("(__fish_complete_directories)")
+
+	case param.ValueType == "hostname":
+		spec += " -x -a " + fishQuote("(__fish_print_hostnames)")
+
+	case param.ValueType == "file" && len(param.Extensions) > 0:
+		calls := make([]string, 0, len(param.Extensions))
+		for _, ext := range param.Extensions {
+			calls = append(calls, "__fish_complete_suffix "+fishEscapeWord(ext))
+		}
+		spec += " -x -a " + fishQuote("("+strings.Join(calls, "; ")+")")
+
+	default: // 非枚举类型的值, 使用文件补全
+		spec += " -r -F"
+	}
+
+	return spec
+}
+
+// generateFishCompletion 生成Fish自动补全脚本
+//
+// 参数:
+//   - buf: 输出缓冲区
+//   - params: 标志参数列表
+//   - root: 根命令
+//   - cmdTreeEntries: 子命令的命令树条目
+//   - programName: 程序名称
+func generateFishCompletion(buf *bytes.Buffer, params []FlagParam, root types.Command, cmdTreeEntries string, programName string) {
+	// 根命令条目在前
+	var completions bytes.Buffer
+	generateFishCommandTreeEntry(&completions, "/", root, programName)
+	completions.WriteString(cmdTreeEntries)
+
+	// 标志条目
+	for _, param := range params {
+		writeFishComplete(&completions, programName, param.CommandPath, fishFlagSpec(param), param.Desc)
+	}
+
+	// 使用命名模板生成Fish自动补全脚本
+	tmpl := strings.NewReplacer(
+		"{{.Completions}}", completions.String(), // complete 命令
+		"{{.Ident}}", scriptIdent(programName), // 函数名和变量名
+		"{{.ProgramName}}", programName, // 程序名称
+	)
+
+	_, _ = tmpl.WriteString(buf, fishTemplate)
+}
+
+// generateFishDynamicCompletion 生成使用动态补全的Fish脚本
+//
+// 参数:
+//   - programName: 程序名称
+//
+// 返回值:
+//   - string: 生成的补全脚本
+//   - error: 生成失败时返回错误
+func generateFishDynamicCompletion(programName string) (string, error) {
+	tmpl := strings.NewReplacer(
+		"{{.Ident}}", scriptIdent(programName), // 函数名
+		"{{.ProgramName}}", programName, // 程序名称
+	)
+
+	var buf bytes.Buffer
+	_, err := tmpl.WriteString(&buf, fishDynamicTemplate)
+	return buf.String(), err
+}
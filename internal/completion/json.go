@@ -0,0 +1,214 @@
+// json.go - JSON 格式的补全输出
+//
+// 该文件实现了 __complete json 指令: 与 all 指令计算相同的补全结果,
+// 以带版本号的 JSON 格式输出, 候选项可以包含空格并带有描述和类型。
+
+package completion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// handleJSON 处理 json 指令
+//
+// 参数:
+//   - root: 根命令实例
+//   - args: [cur, prev, cmd_args...], 与 all 指令相同
+//
+// 返回值:
+//   - error: 处理错误
+//
+// 输出格式:
+//
+//	{"version":1,"context":"/","cur":"","prev":"",...,"candidates":[
+//	{"value":"server","desc":"Manage the server","kind":"command"},
+//	{"value":"--verbose","kind":"flag"}
+//	]}
+//
+// 输出是一个 types.CompleteResponse 对象。除合法的 JSON 外还保证:
+// 第一行包含除候选项之外的所有字段, 每个候选项单独占一行, 最后一行为 "]}",
+// 便于没有 JSON 解析器的 Shell 逐行解析。
+func handleJSON(root types.Command, args []string) error {
+	res, err := completeAll(root, args)
+	if err != nil {
+		return err
+	}
+	return writeJSONResponse(root.Out(), res)
+}
+
+// writeJSONResponse 以 JSON 格式输出补全结果
+//
+// 参数:
+//   - w: 输出目标
+//   - res: 补全结果
+//
+// 返回值:
+//   - error: 编码或写入失败时返回错误
+func writeJSONResponse(w io.Writer, res *allResult) error {
+	resp := buildCompleteResponse(res)
+	items := resp.Candidates
+	resp.Candidates = []types.CompleteItem{}
+
+	// 头部: Candidates 是最后一个字段, 去掉空数组的 "]}" 后逐行写入候选项
+	head, err := encodeJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.TrimSuffix(head, "]}"))
+	buf.WriteByte('\n')
+	for i, item := range items {
+		line, err := encodeJSON(item)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(line)
+		if i < len(items)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("]}\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// encodeJSON 将值编码为单行 JSON, 不转义 HTML 字符
+//
+// 参数:
+//   - v: 要编码的值
+//
+// 返回值:
+//   - string: 不带换行的 JSON 文本
+//   - error: 编码失败时返回错误
+func encodeJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", fmt.Errorf("encode completion response: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// buildCompleteResponse 将补全结果转换为 JSON 输出结构
+//
+// 参数:
+//   - res: 补全结果
+//
+// 返回值:
+//   - types.CompleteResponse: JSON 输出结构
+//
+// 功能说明:
+//   - 候选项的描述依次来自补全函数、标志和子命令, 重复的候选项只保留第一个
+//   - 没有补全函数或值类型提示时推导补全指令: 枚举值和正在输入的标志名不补全文件,
+//     其他情况回退到文件补全
+func buildCompleteResponse(res *allResult) types.CompleteResponse {
+	resp := types.CompleteResponse{
+		Version:    types.CompleteJSONVersion,
+		Context:    res.context,
+		Cur:        res.cur,
+		Prev:       res.prev,
+		Flag:       res.valueFlag,
+		Candidates: make([]types.CompleteItem, 0, len(res.matches)),
+	}
+
+	// 值候选项: 补全函数返回的候选值和枚举值
+	values := make(map[string]string)
+	if res.valueOut != nil {
+		for _, m := range res.valueOut.matches {
+			values[m] = res.valueOut.descs[m]
+		}
+		resp.ValueKind = res.valueOut.kind.String()
+		resp.Directive = res.valueOut.directive
+		resp.Extensions = res.valueOut.extensions
+	} else if res.valueFlag != "" && len(res.enumValues) > 0 {
+		resp.Directive = types.CompletionNoFile
+	} else if res.valueFlag == "" && strings.HasPrefix(res.cur, "-") {
+		resp.Directive = types.CompletionNoFile
+	}
+	for _, v := range res.enumValues {
+		values[v] = ""
+	}
+
+	flagDescs, cmdDescs := describeNames(res.cmd)
+	seen := make(map[string]bool, len(res.matches))
+	for _, m := range res.matches {
+		// 内置标志可能同时出现在命令标志和内置标志列表中, 只保留第一个
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+
+		item := types.CompleteItem{Value: m}
+		if desc, ok := values[m]; ok {
+			item.Kind, item.Desc = types.CandidateKindValue, desc
+		} else if strings.HasPrefix(m, "-") {
+			item.Kind, item.Desc = types.CandidateKindFlag, flagDescs[m]
+		} else if desc, ok := cmdDescs[m]; ok {
+			item.Kind, item.Desc = types.CandidateKindCommand, desc
+		} else {
+			item.Kind = types.CandidateKindValue
+		}
+		resp.Candidates = append(resp.Candidates, item)
+	}
+
+	return resp
+}
+
+// describeNames 收集命令的标志和子命令描述
+//
+// 参数:
+//   - cmd: 命令实例, 可以为nil
+//
+// 返回值:
+//   - map[string]string: 标志名 (带 - 或 -- 前缀, 包括继承的持久标志和取反形式) 到描述的映射
+//   - map[string]string: 子命令长短名到描述的映射
+//
+// 注意事项:
+//   - 描述中的换行等空白字符合并为单个空格
+func describeNames(cmd types.Command) (map[string]string, map[string]string) {
+	flagDescs := make(map[string]string)
+	cmdDescs := make(map[string]string)
+	if cmd == nil {
+		return flagDescs, cmdDescs
+	}
+
+	for _, flag := range append(cmd.Flags(), cmd.InheritedFlags()...) {
+		if flag == nil {
+			continue
+		}
+		desc := strings.Join(strings.Fields(flag.Desc()), " ")
+		if flag.LongName() != "" {
+			flagDescs["--"+flag.LongName()] = desc
+		}
+		if flag.IsNegatable() {
+			flagDescs["--"+types.NegatePrefix+flag.LongName()] = desc
+		}
+		if flag.ShortName() != "" {
+			flagDescs["-"+flag.ShortName()] = desc
+		}
+	}
+
+	for _, sub := range cmd.SubCmds() {
+		if sub == nil {
+			continue
+		}
+		desc := strings.Join(strings.Fields(sub.Desc()), " ")
+		for _, name := range []string{sub.LongName(), sub.ShortName()} {
+			if name != "" {
+				cmdDescs[name] = desc
+			}
+		}
+	}
+
+	return flagDescs, cmdDescs
+}
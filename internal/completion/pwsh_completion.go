@@ -91,12 +91,16 @@ func generatePwshCompletion(buf *bytes.Buffer, params []FlagParam, rootCmdOpts [
 
 	// 处理标志参数
 	for i, param := range params {
-		// 生成带枚举选项的标志参数条目
+		// 生成带枚举选项的标志参数条目, 限定了扩展名的文件值使用扩展名作为选项
 		enumOptions := ""
 		if param.ValueType == "enum" && len(param.EnumOptions) > 0 {
 			optionsBuf := bytes.NewBuffer(make([]byte, 0, len(param.EnumOptions)*15))
 			formatOptions(optionsBuf, param.EnumOptions)
 			enumOptions = optionsBuf.String()
+		} else if param.ValueType == "file" && len(param.Extensions) > 0 {
+			optionsBuf := bytes.NewBuffer(make([]byte, 0, len(param.Extensions)*8))
+			formatOptions(optionsBuf, param.Extensions)
+			enumOptions = optionsBuf.String()
 		}
 
 		// 使用命名占位符替换位置参数
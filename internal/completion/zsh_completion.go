@@ -0,0 +1,170 @@
+// Package completion Zsh 自动补全实现
+// 本文件实现了Zsh环境下的命令行自动补全功能,
+// 生成Zsh补全脚本, 标志和子命令的描述会显示在补全菜单中。
+package completion
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// ZshFuncName 获取程序的Zsh补全函数名
+//
+// 参数:
+//   - programName: 程序名称
+//
+// 返回值:
+//   - string: 补全函数名, 如 "_myapp", 也是放入 fpath 目录时的脚本文件名
+func ZshFuncName(programName string) string {
+	return "_" + scriptIdent(programName)
+}
+
+// zshQuote 将字符串转换为Zsh单引号字符串
+//
+// 参数:
+//   - s: 原始字符串
+//
+// 返回值:
+//   - string: 单引号包裹的字符串, 内部的单引号转义为 '\”
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// zshDescribeEntries 收集命令的补全条目, 格式为 _describe 使用的 "名称:描述"
+//
+// 参数:
+//   - cmd: 命令接口
+//
+// 返回值:
+//   - []string: 按名称排序的条目列表, 包括标志 (含继承的持久标志) 和子命令
+//
+// 注意事项:
+//   - 名称中的冒号转义为 \:, 描述中的换行替换为空格
+func zshDescribeEntries(cmd types.Command) []string {
+	if cmd == nil {
+		return nil
+	}
+
+	seen := make(map[string]string)
+	add := func(name, desc string) {
+		if name != "" {
+			seen[name] = strings.Join(strings.Fields(desc), " ")
+		}
+	}
+
+	// 1. flags (同时展开长短名)
+	for _, flag := range append(cmd.Flags(), cmd.InheritedFlags()...) {
+		if flag == nil {
+			continue
+		}
+		if flag.LongName() != "" {
+			add("--"+flag.LongName(), flag.Desc())
+		}
+		if flag.IsNegatable() {
+			add("--"+types.NegatePrefix+flag.LongName(), flag.Desc())
+		}
+		if flag.ShortName() != "" {
+			add("-"+flag.ShortName(), flag.Desc())
+		}
+	}
+
+	// 2. sub-commands (同时展开长短名)
+	for _, sub := range cmd.SubCmds() {
+		if sub == nil {
+			continue
+		}
+		add(sub.LongName(), sub.Desc())
+		add(sub.ShortName(), sub.Desc())
+	}
+
+	entries := make([]string, 0, len(seen))
+	for name, desc := range seen {
+		entry := strings.ReplaceAll(name, ":", `\:`)
+		if desc != "" {
+			entry += ":" + desc
+		}
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// generateZshCommandTreeEntry 生成Zsh命令树条目
+//
+// 参数:
+//   - cmdTreeEntries: 命令树条目缓冲区
+//   - cmdPath: 命令路径
+//   - cmd: 命令路径对应的命令
+func generateZshCommandTreeEntry(cmdTreeEntries *bytes.Buffer, cmdPath string, cmd types.Command) {
+	cmdTreeEntries.WriteString("  ")
+	cmdTreeEntries.WriteString(zshQuote(cmdPath))
+	cmdTreeEntries.WriteByte(' ')
+	cmdTreeEntries.WriteString(zshQuote(strings.Join(zshDescribeEntries(cmd), "\n")))
+	cmdTreeEntries.WriteByte('\n')
+}
+
+// generateZshCompletion 生成Zsh自动补全脚本
+//
+// 参数:
+//   - buf: 输出缓冲区
+//   - params: 标志参数列表
+//   - root: 根命令
+//   - cmdTreeEntries: 子命令的命令树条目
+//   - programName: 程序名称
+func generateZshCompletion(buf *bytes.Buffer, params []FlagParam, root types.Command, cmdTreeEntries string, programName string) {
+	// 根命令条目在前
+	var cmdTree bytes.Buffer
+	generateZshCommandTreeEntry(&cmdTree, "/", root)
+	cmdTree.WriteString(cmdTreeEntries)
+
+	// 构建标志参数映射和枚举选项
+	var flagParamsBuf bytes.Buffer
+	var enumOptionsBuf bytes.Buffer
+	for _, param := range params {
+		key := zshQuote(param.CommandPath + "|" + param.Name)
+		flagParamsBuf.WriteString("  " + key + " " + zshQuote(param.Type+"|"+param.ValueType) + "\n")
+
+		// 枚举选项每行一个, 允许值中包含空格和 | 字符
+		if param.ValueType == "enum" && len(param.EnumOptions) > 0 {
+			enumOptionsBuf.WriteString("  " + key + " " + zshQuote(strings.Join(param.EnumOptions, "\n")) + "\n")
+		}
+
+		// 限定了扩展名的文件值复用枚举选项映射存储扩展名
+		if param.ValueType == "file" && len(param.Extensions) > 0 {
+			enumOptionsBuf.WriteString("  " + key + " " + zshQuote(strings.Join(param.Extensions, "\n")) + "\n")
+		}
+	}
+
+	// 使用命名模板生成Zsh自动补全脚本
+	tmpl := strings.NewReplacer(
+		"{{.CmdTree}}", cmdTree.String(), // 命令树条目
+		"{{.FlagParams}}", flagParamsBuf.String(), // 标志参数
+		"{{.EnumOptions}}", enumOptionsBuf.String(), // 枚举选项
+		"{{.Ident}}", scriptIdent(programName), // 函数名和变量名
+		"{{.ProgramName}}", programName, // 程序名称
+	)
+
+	_, _ = tmpl.WriteString(buf, zshTemplate)
+}
+
+// generateZshDynamicCompletion 生成使用动态补全的Zsh脚本
+//
+// 参数:
+//   - programName: 程序名称
+//
+// 返回值:
+//   - string: 生成的补全脚本
+//   - error: 生成失败时返回错误
+func generateZshDynamicCompletion(programName string) (string, error) {
+	tmpl := strings.NewReplacer(
+		"{{.Ident}}", scriptIdent(programName), // 函数名
+		"{{.ProgramName}}", programName, // 程序名称
+	)
+
+	var buf bytes.Buffer
+	_, err := tmpl.WriteString(&buf, zshDynamicTemplate)
+	return buf.String(), err
+}
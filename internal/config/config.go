@@ -0,0 +1,115 @@
+// Package config 提供配置文件解码功能
+//
+// config.go - 解码器注册与配置文件加载
+//
+// 该文件按扩展名管理配置文件解码器, 并提供读取和解码配置文件的入口
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+var (
+	decodersMu sync.RWMutex // 保护 decoders 的读写锁
+	// decoders 按扩展名 (小写, 含前导点) 注册的解码器
+	decoders = map[string]types.ConfigDecoder{
+		".json": JSONDecoder{},
+		".toml": INIDecoder{},
+		".ini":  INIDecoder{},
+		".conf": INIDecoder{},
+		".cfg":  INIDecoder{},
+	}
+)
+
+// RegisterDecoder 按扩展名注册配置文件解码器
+//
+// 参数:
+//   - ext: 文件扩展名, 如 ".yaml" 或 "yaml"
+//   - decoder: 解码器
+//
+// 注意事项:
+//   - 扩展名不区分大小写, 重复注册会覆盖之前的解码器
+//   - decoder 为nil时删除该扩展名的解码器
+func RegisterDecoder(ext string, decoder types.ConfigDecoder) {
+	ext = normalizeExt(ext)
+
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	if decoder == nil {
+		delete(decoders, ext)
+		return
+	}
+	decoders[ext] = decoder
+}
+
+// DecoderFor 根据文件扩展名获取解码器
+//
+// 参数:
+//   - path: 配置文件路径
+//
+// 返回值:
+//   - types.ConfigDecoder: 解码器
+//   - error: 扩展名没有对应的解码器时返回错误
+func DecoderFor(path string) (types.ConfigDecoder, error) {
+	ext := normalizeExt(filepath.Ext(path))
+
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	decoder, ok := decoders[ext]
+	if !ok {
+		return nil, fmt.Errorf("unsupported config file extension '%s'", ext)
+	}
+	return decoder, nil
+}
+
+// Load 读取并解码配置文件
+//
+// 参数:
+//   - path: 配置文件路径
+//   - decoder: 解码器, 为nil时按扩展名选择
+//
+// 返回值:
+//   - map[string]any: 解码后的嵌套映射
+//   - error: 读取或解码失败时返回错误, 文件不存在时错误满足 errors.Is(err, fs.ErrNotExist)
+func Load(path string, decoder types.ConfigDecoder) (map[string]any, error) {
+	if decoder == nil {
+		var err error
+		if decoder, err = DecoderFor(path); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file '%s': %w", path, err)
+	}
+
+	values, err := decoder.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode config file '%s': %w", path, err)
+	}
+	return values, nil
+}
+
+// normalizeExt 规范化扩展名为带前导点的小写形式
+//
+// 参数:
+//   - ext: 扩展名
+//
+// 返回值:
+//   - string: 规范化后的扩展名
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
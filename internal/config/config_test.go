@@ -0,0 +1,111 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestINIDecoder(t *testing.T) {
+	data := `# 根命令
+verbose = true
+name = "hello \"world\""   # 行尾注释
+path = 'C:\tmp'
+tags = [a, "b c", 'd']
+empty = []
+
+[server]
+address = :8080
+; 注释
+[server.start]
+retries = 3
+`
+	got, err := INIDecoder{}.Decode([]byte(data))
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+
+	want := map[string]any{
+		"verbose": "true",
+		"name":    `hello "world"`,
+		"path":    `C:\tmp`,
+		"tags":    []any{"a", "b c", "d"},
+		"empty":   []any{},
+		"server": map[string]any{
+			"address": ":8080",
+			"start":   map[string]any{"retries": "3"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %#v, want %#v", got, want)
+	}
+}
+
+func TestINIDecoderErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing equals":      "verbose\n",
+		"unterminated string": "name = \"oops\n",
+		"unterminated array":  "tags = [a, b\n",
+		"bad section":         "[server\n",
+		"section conflict":    "server = 1\n[server]\n",
+		"key conflict":        "[server]\n[other]\n[server.x]\n[server]\nx = 1\n",
+	}
+	for name, data := range tests {
+		if _, err := (INIDecoder{}).Decode([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestJSONDecoder(t *testing.T) {
+	got, err := JSONDecoder{}.Decode([]byte(`{"count": 9007199254740993, "server": {"tls": true}}`))
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if n := got["count"]; n == nil || n.(interface{ String() string }).String() != "9007199254740993" {
+		t.Errorf("count = %v, want exact integer", n)
+	}
+	if server, ok := got["server"].(map[string]any); !ok || server["tls"] != true {
+		t.Errorf("server = %#v", got["server"])
+	}
+
+	if _, err := (JSONDecoder{}).Decode([]byte(`[1, 2]`)); err == nil {
+		t.Error("expected error for non-object json")
+	}
+}
+
+type stubDecoder struct{}
+
+func (stubDecoder) Decode([]byte) (map[string]any, error) {
+	return map[string]any{"custom": "yes"}, nil
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Load(filepath.Join(dir, "missing.json"), nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file error = %v, want fs.ErrNotExist", err)
+	}
+
+	path := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(path, []byte("custom: yes\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if _, err := Load(path, nil); err == nil {
+		t.Error("expected error for unregistered extension")
+	}
+
+	RegisterDecoder("YAML", stubDecoder{})
+	defer RegisterDecoder(".yaml", nil)
+
+	got, err := Load(path, nil)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if got["custom"] != "yes" {
+		t.Errorf("Load = %#v", got)
+	}
+}
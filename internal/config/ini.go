@@ -0,0 +1,266 @@
+// ini.go - 类 TOML/INI 配置文件解码器
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// INIDecoder 类 TOML/INI 格式的配置文件解码器
+//
+// 支持的语法:
+//   - key = value 键值对, 键为标志长名称
+//   - [server] 或 [server.start] 分区, 对应子命令路径
+//   - # 或 ; 开头的注释行, 以及值后面以空白加 # 开始的行尾注释
+//   - "..." 双引号字符串 (支持 Go 风格转义) 和 '...' 单引号字面字符串
+//   - [a, "b", 'c'] 单行数组, 用于切片标志
+//   - 其他值 (数字、布尔值、无引号文本) 原样作为字符串
+//
+// 注意事项:
+//   - 不支持多行字符串、多行数组和内联表
+//   - 重复的键以最后一次出现的值为准
+type INIDecoder struct{}
+
+// Decode 解码类 TOML/INI 配置文件内容
+//
+// 参数:
+//   - data: 配置文件内容
+//
+// 返回值:
+//   - map[string]any: 解码后的嵌套映射
+//   - error: 内容格式错误时返回错误, 错误信息包含行号
+func (INIDecoder) Decode(data []byte) (map[string]any, error) {
+	root := make(map[string]any)
+	section := root
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		// 跳过空行和注释行
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		// 分区
+		if strings.HasPrefix(line, "[") {
+			name, ok := strings.CutSuffix(stripComment(line), "]")
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid section header %q", lineNo, line)
+			}
+			table, err := sectionTable(root, name[1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			section = table
+			continue
+		}
+
+		// 键值对
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", lineNo, line)
+		}
+		key = unquoteKey(strings.TrimSpace(key))
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", lineNo)
+		}
+
+		value, err := parseINIValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if _, isTable := section[key].(map[string]any); isTable {
+			return nil, fmt.Errorf("line %d: key '%s' conflicts with a section", lineNo, key)
+		}
+		section[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// sectionTable 获取 (必要时创建) 分区对应的嵌套映射
+//
+// 参数:
+//   - root: 顶层映射
+//   - name: 分区名称, 以 . 分隔各级
+//
+// 返回值:
+//   - map[string]any: 分区映射
+//   - error: 分区名为空或与已有的键冲突时返回错误
+func sectionTable(root map[string]any, name string) (map[string]any, error) {
+	table := root
+	for _, part := range strings.Split(name, ".") {
+		part = unquoteKey(strings.TrimSpace(part))
+		if part == "" {
+			return nil, fmt.Errorf("invalid section name %q", name)
+		}
+
+		switch next := table[part].(type) {
+		case map[string]any:
+			table = next
+		case nil:
+			child := make(map[string]any)
+			table[part] = child
+			table = child
+		default:
+			return nil, fmt.Errorf("section '%s' conflicts with a key", name)
+		}
+	}
+	return table, nil
+}
+
+// parseINIValue 解析键值对中的值
+//
+// 参数:
+//   - raw: 去除首尾空白的原始值
+//
+// 返回值:
+//   - any: 字符串或字符串数组 ([]any)
+//   - error: 引号或数组未闭合时返回错误
+func parseINIValue(raw string) (any, error) {
+	if strings.HasPrefix(raw, "[") {
+		return parseINIArray(raw)
+	}
+
+	value, rest, err := parseINIScalar(raw)
+	if err != nil {
+		return nil, err
+	}
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, fmt.Errorf("unexpected content after value: %q", rest)
+	}
+	return value, nil
+}
+
+// parseINIArray 解析单行数组
+//
+// 参数:
+//   - raw: 以 [ 开头的原始值
+//
+// 返回值:
+//   - any: 元素均为字符串的 []any
+//   - error: 数组未闭合或元素格式错误时返回错误
+func parseINIArray(raw string) (any, error) {
+	items := []any{}
+	rest := strings.TrimSpace(raw[1:])
+
+	for {
+		if strings.HasPrefix(rest, "]") {
+			break
+		}
+		if rest == "" {
+			return nil, fmt.Errorf("unterminated array")
+		}
+
+		var (
+			item string
+			err  error
+		)
+		if rest[0] == '"' || rest[0] == '\'' {
+			item, rest, err = parseINIScalar(rest)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			end := strings.IndexAny(rest, ",]")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated array")
+			}
+			item, rest = strings.TrimSpace(rest[:end]), rest[end:]
+		}
+		items = append(items, item)
+
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, ",") {
+			rest = strings.TrimSpace(rest[1:])
+		} else if !strings.HasPrefix(rest, "]") {
+			return nil, fmt.Errorf("expected ',' or ']' in array")
+		}
+	}
+
+	if rest = strings.TrimSpace(rest[1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, fmt.Errorf("unexpected content after array: %q", rest)
+	}
+	return items, nil
+}
+
+// parseINIScalar 解析一个标量值
+//
+// 参数:
+//   - raw: 原始值
+//
+// 返回值:
+//   - string: 解析后的值
+//   - string: 值之后剩余的内容 (仅引号字符串会有剩余)
+//   - error: 引号未闭合或转义无效时返回错误
+func parseINIScalar(raw string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(raw, "\""):
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '\\':
+				i++
+			case '"':
+				value, err := strconv.Unquote(raw[:i+1])
+				if err != nil {
+					return "", "", fmt.Errorf("invalid string %s: %w", raw[:i+1], err)
+				}
+				return value, raw[i+1:], nil
+			}
+		}
+		return "", "", fmt.Errorf("unterminated string %s", raw)
+
+	case strings.HasPrefix(raw, "'"):
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string %s", raw)
+		}
+		return raw[1 : end+1], raw[end+2:], nil
+
+	default:
+		return strings.TrimSpace(stripComment(raw)), "", nil
+	}
+}
+
+// stripComment 去除无引号内容中以空白加 # 开始的行尾注释
+//
+// 参数:
+//   - s: 内容
+//
+// 返回值:
+//   - string: 去除注释后的内容
+func stripComment(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return s
+}
+
+// unquoteKey 去除键两侧的引号
+//
+// 参数:
+//   - key: 键
+//
+// 返回值:
+//   - string: 去除引号后的键
+func unquoteKey(key string) string {
+	if len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+		return key[1 : len(key)-1]
+	}
+	return key
+}
@@ -0,0 +1,33 @@
+// json.go - JSON 配置文件解码器
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONDecoder JSON 格式的配置文件解码器
+//
+// 数字解码为 json.Number, 以保留整数的完整精度
+type JSONDecoder struct{}
+
+// Decode 解码 JSON 配置文件内容
+//
+// 参数:
+//   - data: 配置文件内容
+//
+// 返回值:
+//   - map[string]any: 解码后的嵌套映射
+//   - error: 内容不是 JSON 对象时返回错误
+func (JSONDecoder) Decode(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	values := make(map[string]any)
+	if err := dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+	return values, nil
+}
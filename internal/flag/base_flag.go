@@ -38,6 +38,14 @@ type BaseFlag[T any] struct {
 	envVar    string             // 关联的环境变量名
 	validator types.Validator[T] // 验证器函数
 
+	implicitValue string // 不带值出现时使用的隐式值
+	hasImplicit   bool   // 是否设置了隐式值
+
+	completion types.CompletionFunc // 值的动态补全函数
+	valueHint  *types.ValueHint     // 显式声明的值类型提示
+
+	origin types.ValueOrigin // 当前值的来源信息
+
 	// 不可变属性, 无需挂锁
 	longName  string         // 长选项名称
 	shortName string         // 短选项名称
@@ -193,6 +201,144 @@ func (f *BaseFlag[T]) EnumValues() []string {
 	return []string{}
 }
 
+// IsNegatable 检查标志是否支持取反形式
+//
+// 返回值:
+//   - bool: 是否支持 --no-<name> 形式
+//
+// 功能说明:
+//   - 实现 Flag 接口的 IsNegatable 方法
+//   - 默认返回false, 布尔标志可以重写此方法
+func (f *BaseFlag[T]) IsNegatable() bool {
+	return false
+}
+
+// ImplicitValue 获取标志的隐式值
+//
+// 返回值:
+//   - string: 标志不带值出现时使用的值
+//   - bool: 是否设置了隐式值
+//
+// 功能说明:
+//   - 实现 Flag 接口的 ImplicitValue 方法
+//   - 默认未设置, 字符串、枚举、整数和持续时间标志可以通过 SetImplicitValue 设置
+func (f *BaseFlag[T]) ImplicitValue() (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.implicitValue, f.hasImplicit
+}
+
+// CompletionFunc 获取标志值的动态补全函数
+//
+// 返回值:
+//   - types.CompletionFunc: 补全函数, 未设置时返回nil
+//
+// 功能说明:
+//   - 实现 Flag 接口的 CompletionFunc 方法
+func (f *BaseFlag[T]) CompletionFunc() types.CompletionFunc {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.completion
+}
+
+// SetCompletionFunc 设置标志值的动态补全函数
+//
+// 参数:
+//   - fn: 补全函数, 为nil时清除
+//
+// 功能说明:
+//   - 动态补全 (__complete 指令) 补全该标志的值时调用 fn 获取候选项
+//   - 位置参数的值容器同样适用, 如 cmd.StringArg(...).SetCompletionFunc(fn)
+//   - 静态补全脚本不会调用补全函数
+func (f *BaseFlag[T]) SetCompletionFunc(fn types.CompletionFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.completion = fn
+}
+
+// ValueHint 获取标志值的类型提示
+//
+// 返回值:
+//   - types.ValueHint: 值类型提示, 未声明时 Kind 为 types.ValueKindAuto
+//
+// 功能说明:
+//   - 实现 Flag 接口的 ValueHint 方法
+//   - 返回通过 SetValueHint 声明的值类型, 验证器不影响值类型
+func (f *BaseFlag[T]) ValueHint() types.ValueHint {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.valueHint != nil {
+		return *f.valueHint
+	}
+	return types.ValueHint{}
+}
+
+// SetValueHint 声明标志值的类型
+//
+// 参数:
+//   - kind: 值类型
+//   - extensions: ValueKindFile 允许的扩展名, 如 "yaml" 或 ".yaml"
+//
+// 功能说明:
+//   - 静态和动态补全脚本按值类型补全: 文件 (可限定扩展名)、目录、主机名或不补全
+//   - 位置参数的值容器同样适用, 动态补全会按其值类型补全位置参数
+//   - 设置了 FileExists、DirExists 等验证器的标志同样需要调用该方法声明值类型
+func (f *BaseFlag[T]) SetValueHint(kind types.ValueKind, extensions ...string) {
+	hint := &types.ValueHint{Kind: kind}
+	for _, ext := range extensions {
+		if ext = strings.TrimSpace(ext); ext != "" {
+			hint.Extensions = append(hint.Extensions, "."+strings.TrimPrefix(ext, "."))
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.valueHint = hint
+}
+
+// Origin 获取标志当前值的来源信息
+//
+// 返回值:
+//   - types.ValueOrigin: 来源、原始字符串和来源位置
+//
+// 功能说明:
+//   - 实现 Flag 接口的 Origin 方法
+//   - 未设置时返回默认值来源, 已设置但未记录来源时返回程序设置来源
+func (f *BaseFlag[T]) Origin() types.ValueOrigin {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if !f.isSet {
+		return types.ValueOrigin{Source: types.SourceDefault, Raw: fmt.Sprintf("%v", f.default_)}
+	}
+	if f.origin.Source == types.SourceDefault {
+		return types.ValueOrigin{Source: types.SourceProgram, Raw: fmt.Sprintf("%v", *f.value)}
+	}
+	return f.origin
+}
+
+// SetOrigin 记录标志当前值的来源信息
+//
+// 参数:
+//   - origin: 来源信息
+func (f *BaseFlag[T]) SetOrigin(origin types.ValueOrigin) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.origin = origin
+}
+
+// setImplicitValue 设置标志的隐式值
+//
+// 参数:
+//   - value: 隐式值的字符串形式
+func (f *BaseFlag[T]) setImplicitValue(value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.implicitValue = value
+	f.hasImplicit = true
+}
+
 // Set 设置标志的值
 //
 // 参数:
@@ -224,6 +370,7 @@ func (f *BaseFlag[T]) Reset() {
 
 	*f.value = f.default_
 	f.isSet = false
+	f.origin = types.ValueOrigin{}
 }
 
 // String 返回标志的格式化名称
@@ -2,8 +2,11 @@ package flag
 
 import (
 	"os"
+	"slices"
 	"strings"
 	"testing"
+
+	"gitee.com/MM-Q/qflag/internal/types"
 )
 
 func TestAutoBindEnv(t *testing.T) {
@@ -141,3 +144,60 @@ func TestBindEnvVsAutoBindEnv(t *testing.T) {
 		t.Errorf("AutoBindEnv 错误, 期望 HOST, 得到 %s", got)
 	}
 }
+
+func TestFlagOrigin(t *testing.T) {
+	f := NewIntFlag("port", "p", "端口", 8080)
+
+	if got := f.Origin(); got.Source != types.SourceDefault || got.Raw != "8080" {
+		t.Errorf("default origin = %+v", got)
+	}
+
+	// 程序中直接设置, 未记录来源
+	if err := f.Set("9090"); err != nil {
+		t.Fatalf("Set error: %v", err)
+	}
+	if got := f.Origin(); got.Source != types.SourceProgram || got.Raw != "9090" {
+		t.Errorf("program origin = %+v", got)
+	}
+
+	want := types.ValueOrigin{Source: types.SourceEnv, Raw: "9090", Location: "APP_PORT"}
+	f.SetOrigin(want)
+	if got := f.Origin(); got != want {
+		t.Errorf("Origin() = %+v, want %+v", got, want)
+	}
+
+	f.Reset()
+	if got := f.Origin(); got.Source != types.SourceDefault {
+		t.Errorf("origin after Reset = %+v", got)
+	}
+}
+
+func TestFlagValueHint(t *testing.T) {
+	f := NewStringFlag("config", "c", "配置文件", "")
+	if got := f.ValueHint(); got.Kind != types.ValueKindAuto {
+		t.Errorf("default hint = %+v", got)
+	}
+
+	// 验证器不影响值类型, 也不会在查询值类型时被调用
+	f.SetValidator(func(v string) error {
+		t.Errorf("validator called with %q", v)
+		return nil
+	})
+	if got := f.ValueHint(); got.Kind != types.ValueKindAuto {
+		t.Errorf("hint with validator = %+v", got)
+	}
+
+	// 扩展名统一为带点号的形式
+	f.SetValueHint(types.ValueKindFile, "yaml", ".yml", " ")
+	if got := f.ValueHint(); got.Kind != types.ValueKindFile || !slices.Equal(got.Extensions, []string{".yaml", ".yml"}) {
+		t.Errorf("file hint = %+v", got)
+	}
+	if got := f.ValueHint().Directive(); got != types.CompletionFilterExt {
+		t.Errorf("Directive() = %v, want CompletionFilterExt", got)
+	}
+
+	f.SetValueHint(types.ValueKindNone)
+	if got := f.ValueHint(); got.Kind != types.ValueKindNone || got.Directive() != types.CompletionNoFile {
+		t.Errorf("explicit hint = %+v", got)
+	}
+}
@@ -68,12 +68,27 @@ func (f *StringFlag) Set(value string) error {
 	return nil
 }
 
+// SetImplicitValue 设置字符串标志的隐式值
+//
+// 参数:
+//   - value: 标志不带值出现时使用的值
+//
+// 功能说明:
+//   - 设置后标志可以不带值出现, 如 --debug 等同于 --debug=info
+//   - 显式的值必须使用 --name=value 或 -svalue 形式, 下一个参数不会被当作标志值
+//   - 隐式值在使用时经过与普通值相同的解析和验证
+func (f *StringFlag) SetImplicitValue(value string) {
+	f.setImplicitValue(value)
+}
+
 // BoolFlag 布尔标志
 //
 // BoolFlag 用于处理布尔类型的命令行参数。
 // 它接受多种布尔值表示形式, 包括 "true", "false", "1", "0", "t", "f", "TRUE", "FALSE" 等。
+// 开启取反形式后, 还接受 --no-<name> 将值设置为 false。
 type BoolFlag struct {
 	*BaseFlag[bool]
+	negatable bool // 是否支持 --no-<name> 取反形式
 }
 
 // NewBoolFlag 创建布尔标志
@@ -136,3 +151,28 @@ func (f *BoolFlag) Set(value string) error {
 
 	return nil
 }
+
+// SetNegatable 设置是否支持取反形式
+//
+// 参数:
+//   - negatable: 是否支持 --no-<name> 取反形式
+//
+// 功能说明:
+//   - 开启后 --no-<name> 将值设置为 false, 并标记为已设置
+//   - 适用于默认值为 true 或绑定了环境变量的标志, 在命令行上显式关闭
+//   - 只有设置了长名称的标志才能使用取反形式
+func (f *BoolFlag) SetNegatable(negatable bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.negatable = negatable
+}
+
+// IsNegatable 检查标志是否支持取反形式
+//
+// 返回值:
+//   - bool: 是否支持 --no-<name> 取反形式
+func (f *BoolFlag) IsNegatable() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.negatable && f.longName != ""
+}
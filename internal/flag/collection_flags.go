@@ -2,6 +2,8 @@ package flag
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -11,6 +13,8 @@ import (
 // StringSliceFlag 字符串切片标志
 type StringSliceFlag struct {
 	*BaseFlag[[]string]
+	accumulate bool // 是否开启累积模式
+	appended   bool // 当前值是否由累积操作产生
 }
 
 // NewStringSliceFlag 创建新的字符串切片标志
@@ -29,21 +33,129 @@ func (f *StringSliceFlag) Set(value string) error {
 	if value == "" {
 		*f.value = []string{}
 		f.isSet = true
+		f.appended = false
 		return nil
 	}
 
 	// 使用逗号分割字符串
-	parts := strings.Split(value, ",")
+	result := parseStringSlice(value)
 
-	// 过滤掉空字符串元素
-	result := make([]string, 0, len(parts))
-	for _, part := range parts {
-		trimmed := strings.TrimSpace(part)
-		if trimmed != "" {
-			result = append(result, trimmed)
+	// 验证（如果设置了验证器）
+	if f.validator != nil {
+		if err := f.validator(result); err != nil {
+			return err
 		}
 	}
 
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = false
+
+	return nil
+}
+
+// Append 累积设置字符串切片标志的值
+//
+// 参数:
+//   - value: 逗号分隔的字符串
+//
+// 返回值:
+//   - error: 解析失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 用于累积模式下重复出现的标志
+//   - 第一次累积时替换默认值或环境变量值, 之后追加到已有值
+//   - 不在此处执行验证, 由解析器在命令行解析完成后调用 Validate 统一验证
+func (f *StringSliceFlag) Append(value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := parseStringSlice(value)
+	if f.isSet && f.appended {
+		result = append(slices.Clone(*f.value), result...)
+	}
+
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = true
+
+	return nil
+}
+
+// Validate 验证累积后的完整值
+//
+// 返回值:
+//   - error: 验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 由解析器在命令行解析完成后调用一次
+//   - 仅在当前值由累积操作产生时执行验证器, 验证对象为合并后的完整切片
+func (f *StringSliceFlag) Validate() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.validator == nil || !f.appended {
+		return nil
+	}
+	return f.validator(*f.value)
+}
+
+// Reset 重置标志为默认值
+//
+// 功能说明:
+//   - 在 BaseFlag.Reset 的基础上清除累积状态, 重新解析时默认值不会被当作累积结果验证
+func (f *StringSliceFlag) Reset() {
+	f.BaseFlag.Reset()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.appended = false
+}
+
+// SetAccumulate 设置是否开启累积模式
+//
+// 参数:
+//   - accumulate: 是否开启累积模式
+//
+// 功能说明:
+//   - 开启后重复出现的标志追加元素, 如 --tag a --tag b 得到 [a b]
+//   - 关闭时每次出现都替换整个值
+func (f *StringSliceFlag) SetAccumulate(accumulate bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accumulate = accumulate
+}
+
+// IsAccumulate 检查是否开启累积模式
+//
+// 返回值:
+//   - bool: 是否开启累积模式
+func (f *StringSliceFlag) IsAccumulate() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.accumulate
+}
+
+// SetItems 逐项设置字符串切片标志的值
+//
+// 参数:
+//   - items: 值列表, 每一项作为一个元素, 不按逗号分割
+//
+// 返回值:
+//   - error: 验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.ItemSetter 接口, 用于可变位置参数
+//   - 验证器作用于完整的结果切片
+func (f *StringSliceFlag) SetItems(items []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]string, len(items))
+	copy(result, items)
+
 	// 验证（如果设置了验证器）
 	if f.validator != nil {
 		if err := f.validator(result); err != nil {
@@ -54,6 +166,7 @@ func (f *StringSliceFlag) Set(value string) error {
 	// 设置值并标记为已设置
 	*f.value = result
 	f.isSet = true
+	f.appended = false
 
 	return nil
 }
@@ -75,6 +188,8 @@ func (f *StringSliceFlag) IsEmpty() bool {
 // IntSliceFlag 整数切片标志
 type IntSliceFlag struct {
 	*BaseFlag[[]int]
+	accumulate bool // 是否开启累积模式
+	appended   bool // 当前值是否由累积操作产生
 }
 
 // NewIntSliceFlag 创建新的整数切片标志
@@ -93,25 +208,137 @@ func (f *IntSliceFlag) Set(value string) error {
 	if value == "" {
 		*f.value = []int{}
 		f.isSet = true
+		f.appended = false
 		return nil
 	}
 
-	// 使用逗号分割字符串
-	parts := strings.Split(value, ",")
-
-	// 转换为整数
-	result := make([]int, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
+	// 使用逗号分割字符串并转换为整数
+	result, err := parseIntSlice(value)
+	if err != nil {
+		return err
+	}
 
-		// 跳过空字符串
-		if part == "" {
-			continue
+	// 验证（如果设置了验证器）
+	if f.validator != nil {
+		if err := f.validator(result); err != nil {
+			return err
 		}
+	}
 
-		n, err := strconv.Atoi(part)
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = false
+
+	return nil
+}
+
+// Append 累积设置整数切片标志的值
+//
+// 参数:
+//   - value: 逗号分隔的整数字符串
+//
+// 返回值:
+//   - error: 解析或验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 用于累积模式下重复出现的标志
+//   - 第一次累积时替换默认值或环境变量值, 之后追加到已有值
+//   - 不在此处执行验证, 由解析器在命令行解析完成后调用 Validate 统一验证
+func (f *IntSliceFlag) Append(value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result, err := parseIntSlice(value)
+	if err != nil {
+		return err
+	}
+	if f.isSet && f.appended {
+		result = append(slices.Clone(*f.value), result...)
+	}
+
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = true
+
+	return nil
+}
+
+// Validate 验证累积后的完整值
+//
+// 返回值:
+//   - error: 验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 由解析器在命令行解析完成后调用一次
+//   - 仅在当前值由累积操作产生时执行验证器, 验证对象为合并后的完整切片
+func (f *IntSliceFlag) Validate() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.validator == nil || !f.appended {
+		return nil
+	}
+	return f.validator(*f.value)
+}
+
+// Reset 重置标志为默认值
+//
+// 功能说明:
+//   - 在 BaseFlag.Reset 的基础上清除累积状态, 重新解析时默认值不会被当作累积结果验证
+func (f *IntSliceFlag) Reset() {
+	f.BaseFlag.Reset()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.appended = false
+}
+
+// SetAccumulate 设置是否开启累积模式
+//
+// 参数:
+//   - accumulate: 是否开启累积模式
+//
+// 功能说明:
+//   - 开启后重复出现的标志追加元素, 如 --port 80 --port 443 得到 [80 443]
+//   - 关闭时每次出现都替换整个值
+func (f *IntSliceFlag) SetAccumulate(accumulate bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accumulate = accumulate
+}
+
+// IsAccumulate 检查是否开启累积模式
+//
+// 返回值:
+//   - bool: 是否开启累积模式
+func (f *IntSliceFlag) IsAccumulate() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.accumulate
+}
+
+// SetItems 逐项设置整数切片标志的值
+//
+// 参数:
+//   - items: 值列表, 每一项解析为一个元素, 不按逗号分割
+//
+// 返回值:
+//   - error: 解析或验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.ItemSetter 接口, 用于可变位置参数
+//   - 验证器作用于完整的结果切片
+func (f *IntSliceFlag) SetItems(items []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
 		if err != nil {
-			return fmt.Errorf("parse int '%s': %w", part, err)
+			return fmt.Errorf("parse int '%s': %w", item, err)
 		}
 		result = append(result, n)
 	}
@@ -126,6 +353,7 @@ func (f *IntSliceFlag) Set(value string) error {
 	// 设置值并标记为已设置
 	*f.value = result
 	f.isSet = true
+	f.appended = false
 
 	return nil
 }
@@ -147,6 +375,8 @@ func (f *IntSliceFlag) IsEmpty() bool {
 // Int64SliceFlag 64位整数切片标志
 type Int64SliceFlag struct {
 	*BaseFlag[[]int64]
+	accumulate bool // 是否开启累积模式
+	appended   bool // 当前值是否由累积操作产生
 }
 
 // NewInt64SliceFlag 创建新的64位整数切片标志
@@ -165,25 +395,137 @@ func (f *Int64SliceFlag) Set(value string) error {
 	if value == "" {
 		*f.value = []int64{}
 		f.isSet = true
+		f.appended = false
 		return nil
 	}
 
-	// 使用逗号分割字符串
-	parts := strings.Split(value, ",")
-
-	// 转换为64位整数
-	result := make([]int64, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
+	// 使用逗号分割字符串并转换为64位整数
+	result, err := parseInt64Slice(value)
+	if err != nil {
+		return err
+	}
 
-		// 跳过空字符串
-		if part == "" {
-			continue
+	// 验证（如果设置了验证器）
+	if f.validator != nil {
+		if err := f.validator(result); err != nil {
+			return err
 		}
+	}
 
-		n, err := strconv.ParseInt(part, 10, 64)
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = false
+
+	return nil
+}
+
+// Append 累积设置64位整数切片标志的值
+//
+// 参数:
+//   - value: 逗号分隔的64位整数字符串
+//
+// 返回值:
+//   - error: 解析或验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 用于累积模式下重复出现的标志
+//   - 第一次累积时替换默认值或环境变量值, 之后追加到已有值
+//   - 不在此处执行验证, 由解析器在命令行解析完成后调用 Validate 统一验证
+func (f *Int64SliceFlag) Append(value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result, err := parseInt64Slice(value)
+	if err != nil {
+		return err
+	}
+	if f.isSet && f.appended {
+		result = append(slices.Clone(*f.value), result...)
+	}
+
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = true
+
+	return nil
+}
+
+// Validate 验证累积后的完整值
+//
+// 返回值:
+//   - error: 验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 由解析器在命令行解析完成后调用一次
+//   - 仅在当前值由累积操作产生时执行验证器, 验证对象为合并后的完整切片
+func (f *Int64SliceFlag) Validate() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.validator == nil || !f.appended {
+		return nil
+	}
+	return f.validator(*f.value)
+}
+
+// Reset 重置标志为默认值
+//
+// 功能说明:
+//   - 在 BaseFlag.Reset 的基础上清除累积状态, 重新解析时默认值不会被当作累积结果验证
+func (f *Int64SliceFlag) Reset() {
+	f.BaseFlag.Reset()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.appended = false
+}
+
+// SetAccumulate 设置是否开启累积模式
+//
+// 参数:
+//   - accumulate: 是否开启累积模式
+//
+// 功能说明:
+//   - 开启后重复出现的标志追加元素, 如 --port 80 --port 443 得到 [80 443]
+//   - 关闭时每次出现都替换整个值
+func (f *Int64SliceFlag) SetAccumulate(accumulate bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accumulate = accumulate
+}
+
+// IsAccumulate 检查是否开启累积模式
+//
+// 返回值:
+//   - bool: 是否开启累积模式
+func (f *Int64SliceFlag) IsAccumulate() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.accumulate
+}
+
+// SetItems 逐项设置64位整数切片标志的值
+//
+// 参数:
+//   - items: 值列表, 每一项解析为一个元素, 不按逗号分割
+//
+// 返回值:
+//   - error: 解析或验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.ItemSetter 接口, 用于可变位置参数
+//   - 验证器作用于完整的结果切片
+func (f *Int64SliceFlag) SetItems(items []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]int64, 0, len(items))
+	for _, item := range items {
+		n, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
 		if err != nil {
-			return fmt.Errorf("parse int64 '%s': %w", part, err)
+			return fmt.Errorf("parse int64 '%s': %w", item, err)
 		}
 		result = append(result, n)
 	}
@@ -198,6 +540,7 @@ func (f *Int64SliceFlag) Set(value string) error {
 	// 设置值并标记为已设置
 	*f.value = result
 	f.isSet = true
+	f.appended = false
 
 	return nil
 }
@@ -226,6 +569,8 @@ func (f *Int64SliceFlag) IsEmpty() bool {
 //   - 使用 Clear 方法可以清空映射
 type MapFlag struct {
 	*BaseFlag[map[string]string]
+	accumulate bool // 是否开启累积模式
+	appended   bool // 当前值是否由累积操作产生
 }
 
 // NewMapFlag 创建新的映射标志
@@ -271,33 +616,14 @@ func (f *MapFlag) Set(value string) error {
 	if value == "" {
 		*f.value = make(map[string]string)
 		f.isSet = true
+		f.appended = false
 		return nil
 	}
 
 	// 使用逗号分割字符串, 然后对每个键值对用等号分割
-	result := make(map[string]string)
-	pairs := strings.Split(value, ",")
-
-	for _, pair := range pairs {
-		pair = strings.TrimSpace(pair)
-		if pair == "" {
-			// 跳过空对, 但继续处理其他对
-			continue
-		}
-
-		parts := strings.SplitN(pair, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid map format '%s'", pair)
-		}
-
-		key := strings.TrimSpace(parts[0])
-		val := strings.TrimSpace(parts[1])
-
-		if key == "" {
-			return fmt.Errorf("empty key in map '%s'", pair)
-		}
-
-		result[key] = val
+	result, err := parseMap(value)
+	if err != nil {
+		return err
 	}
 
 	// 验证（如果设置了验证器）
@@ -310,10 +636,99 @@ func (f *MapFlag) Set(value string) error {
 	// 设置值并标记为已设置
 	*f.value = result
 	f.isSet = true
+	f.appended = false
+
+	return nil
+}
+
+// Append 累积设置映射标志的值
+//
+// 参数:
+//   - value: 映射字符串, 格式为 key1=value1,key2=value2
+//
+// 返回值:
+//   - error: 解析或验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 用于累积模式下重复出现的标志
+//   - 第一次累积时替换默认值或环境变量值, 之后合并到已有值, 同名键以后出现的为准
+//   - 不在此处执行验证, 由解析器在命令行解析完成后调用 Validate 统一验证
+func (f *MapFlag) Append(value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result, err := parseMap(value)
+	if err != nil {
+		return err
+	}
+	if f.isSet && f.appended {
+		merged := maps.Clone(*f.value)
+		maps.Copy(merged, result)
+		result = merged
+	}
+
+	// 设置值并标记为已设置
+	*f.value = result
+	f.isSet = true
+	f.appended = true
 
 	return nil
 }
 
+// Validate 验证累积后的完整值
+//
+// 返回值:
+//   - error: 验证失败时返回错误
+//
+// 功能说明:
+//   - 实现 types.Accumulator 接口, 由解析器在命令行解析完成后调用一次
+//   - 仅在当前值由累积操作产生时执行验证器, 验证对象为合并后的完整映射
+func (f *MapFlag) Validate() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.validator == nil || !f.appended {
+		return nil
+	}
+	return f.validator(*f.value)
+}
+
+// Reset 重置标志为默认值
+//
+// 功能说明:
+//   - 在 BaseFlag.Reset 的基础上清除累积状态, 重新解析时默认值不会被当作累积结果验证
+func (f *MapFlag) Reset() {
+	f.BaseFlag.Reset()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.appended = false
+}
+
+// SetAccumulate 设置是否开启累积模式
+//
+// 参数:
+//   - accumulate: 是否开启累积模式
+//
+// 功能说明:
+//   - 开启后重复出现的标志合并键值对, 如 --label a=1 --label b=2 得到 {a:1 b:2}
+//   - 关闭时每次出现都替换整个映射
+func (f *MapFlag) SetAccumulate(accumulate bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accumulate = accumulate
+}
+
+// IsAccumulate 检查是否开启累积模式
+//
+// 返回值:
+//   - bool: 是否开启累积模式
+func (f *MapFlag) IsAccumulate() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.accumulate
+}
+
 // Length 获取映射长度
 func (f *MapFlag) Length() int {
 	f.mu.RLock()
@@ -336,6 +751,7 @@ func (f *MapFlag) Clear() {
 
 	*f.value = make(map[string]string)
 	f.isSet = true
+	f.appended = false
 }
 
 // GetKey 获取映射中指定键的值
@@ -375,3 +791,118 @@ func (f *MapFlag) Keys() []string {
 	}
 	return keys
 }
+
+// parseStringSlice 将逗号分隔的字符串解析为字符串切片
+//
+// 参数:
+//   - value: 逗号分隔的字符串
+//
+// 返回值:
+//   - []string: 去除空白和空元素后的切片
+func parseStringSlice(value string) []string {
+	parts := strings.Split(value, ",")
+
+	// 过滤掉空字符串元素
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// parseIntSlice 将逗号分隔的字符串解析为整数切片
+//
+// 参数:
+//   - value: 逗号分隔的整数字符串
+//
+// 返回值:
+//   - []int: 解析结果, 空元素会被跳过
+//   - error: 元素不是有效整数时返回错误
+func parseIntSlice(value string) ([]int, error) {
+	parts := strings.Split(value, ",")
+
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+
+		// 跳过空字符串
+		if part == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("parse int '%s': %w", part, err)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// parseInt64Slice 将逗号分隔的字符串解析为64位整数切片
+//
+// 参数:
+//   - value: 逗号分隔的整数字符串
+//
+// 返回值:
+//   - []int64: 解析结果, 空元素会被跳过
+//   - error: 元素不是有效整数时返回错误
+func parseInt64Slice(value string) ([]int64, error) {
+	parts := strings.Split(value, ",")
+
+	result := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+
+		// 跳过空字符串
+		if part == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse int64 '%s': %w", part, err)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// parseMap 将 key1=value1,key2=value2 格式的字符串解析为映射
+//
+// 参数:
+//   - value: 映射字符串
+//
+// 返回值:
+//   - map[string]string: 解析结果, 空对会被跳过
+//   - error: 格式错误或键为空时返回错误
+func parseMap(value string) (map[string]string, error) {
+	result := make(map[string]string)
+	pairs := strings.Split(value, ",")
+
+	for _, pair := range pairs {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			// 跳过空对, 但继续处理其他对
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid map format '%s'", pair)
+		}
+
+		key := strings.TrimSpace(parts[0])
+		val := strings.TrimSpace(parts[1])
+
+		if key == "" {
+			return nil, fmt.Errorf("empty key in map '%s'", pair)
+		}
+
+		result[key] = val
+	}
+	return result, nil
+}
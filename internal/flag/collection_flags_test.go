@@ -1,6 +1,7 @@
 package flag
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -279,3 +280,92 @@ func TestMapFlag(t *testing.T) {
 		t.Errorf("Expected type 'map', got '%s'", flag.Type().String())
 	}
 }
+
+// TestCollectionFlagsAppend 测试切片和映射标志的累积设置
+func TestCollectionFlagsAppend(t *testing.T) {
+	tags := NewStringSliceFlag("tag", "t", "标签", []string{"default"})
+	tags.SetAccumulate(true)
+	if !tags.IsAccumulate() {
+		t.Fatal("Expected accumulate mode enabled")
+	}
+
+	// 第一次累积替换默认值, 之后追加
+	for _, v := range []string{"a", "b,c"} {
+		if err := tags.Append(v); err != nil {
+			t.Fatalf("Unexpected error appending %q: %v", v, err)
+		}
+	}
+	if got := tags.Get(); len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Errorf("Expected [a b c], got %v", got)
+	}
+
+	// Set 替换整个值, 之后的第一次累积再次替换
+	if err := tags.Set("env"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := tags.Append("x"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 1 || got[0] != "x" {
+		t.Errorf("Expected [x], got %v", got)
+	}
+
+	// Append 不执行验证, Validate 作用于合并后的值
+	ports := NewIntSliceFlag("port", "p", "端口", nil)
+	ports.SetValidator(func(v []int) error {
+		if len(v) < 2 {
+			return fmt.Errorf("too few ports: %d", len(v))
+		}
+		return nil
+	})
+	if err := ports.Validate(); err != nil {
+		t.Errorf("Expected no validation before append, got %v", err)
+	}
+	if err := ports.Append("80"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ports.Validate(); err == nil {
+		t.Error("Expected validator error on partial value")
+	}
+	if err := ports.Append("443"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ports.Validate(); err != nil {
+		t.Errorf("Expected merged value to pass validation, got %v", err)
+	}
+
+	// Reset 清除累积状态, 默认值不再被当作累积结果验证
+	calls := 0
+	names := NewStringSliceFlag("name", "n", "名称", []string{"default"})
+	names.SetAccumulate(true)
+	names.SetValidator(func(v []string) error {
+		calls++
+		return fmt.Errorf("validator called with %v", v)
+	})
+	_ = names.Append("a")
+	names.Reset()
+	if err := names.Validate(); err != nil || calls != 0 {
+		t.Errorf("Expected no validation after Reset, got err=%v calls=%d", err, calls)
+	}
+
+	// 映射合并, 同名键以后出现的为准
+	labels := NewMapFlag("label", "l", "标签", map[string]string{"env": "dev"})
+	for _, v := range []string{"k1=v1", "k2=v2,k1=x"} {
+		if err := labels.Append(v); err != nil {
+			t.Fatalf("Unexpected error appending %q: %v", v, err)
+		}
+	}
+	got := labels.Get()
+	if len(got) != 2 || got["k1"] != "x" || got["k2"] != "v2" {
+		t.Errorf("Expected {k1:x k2:v2}, got %v", got)
+	}
+
+	// 重置后重新开始累积
+	labels.Reset()
+	if err := labels.Append("k3=v3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := labels.Get(); len(got) != 1 || got["k3"] != "v3" {
+		t.Errorf("Expected {k3:v3} after reset, got %v", got)
+	}
+}
@@ -86,6 +86,93 @@ func (f *IntFlag) Set(value string) error {
 	return nil
 }
 
+// SetImplicitValue 设置整数标志的隐式值
+//
+// 参数:
+//   - value: 标志不带值出现时使用的值
+//
+// 功能说明:
+//   - 设置后标志可以不带值出现, 如 --jobs 等同于 --jobs=4
+//   - 显式的值必须使用 --name=value 或 -svalue 形式, 下一个参数不会被当作标志值
+//   - 隐式值在使用时经过与普通值相同的解析和验证
+func (f *IntFlag) SetImplicitValue(value int) {
+	f.setImplicitValue(strconv.Itoa(value))
+}
+
+// CountFlag 计数标志
+//
+// CountFlag 用于处理 -v -v -v 或 -vvv 这类重复出现的开关。
+// 每出现一次计数加一, 也可以通过 --verbose=3 显式设置计数。
+//
+// 注意事项:
+//   - 值类型为 int, 可以使用整数验证器, 如 validators.IntRange
+//   - 不带值出现时不会取走下一个参数
+//   - 计数从0开始, 默认值和环境变量、配置文件中的值不参与累加
+type CountFlag struct {
+	*BaseFlag[int]
+}
+
+// NewCountFlag 创建计数标志
+//
+// 参数:
+//   - longName: 长选项名, 如 "verbose"
+//   - shortName: 短选项名, 如 "v"
+//   - desc: 标志描述
+//   - default_: 默认值
+//
+// 返回值:
+//   - *CountFlag: 计数标志实例
+func NewCountFlag(longName, shortName, desc string, default_ int) *CountFlag {
+	return &CountFlag{
+		BaseFlag: NewBaseFlag(types.FlagTypeCount, longName, shortName, desc, default_),
+	}
+}
+
+// Set 设置计数标志的值
+//
+// 参数:
+//   - value: 空字符串表示计数加一, 否则为显式设置的计数
+//
+// 返回值:
+//   - error: 如果解析失败或验证失败返回错误
+//
+// 注意事项:
+//   - 空字符串在当前值的基础上加一; 标志未设置或当前值来自环境变量、配置文件时从0开始计数
+//   - 非空字符串解析为整数并直接设置
+//   - 验证器作用于最终的计数值
+func (f *CountFlag) Set(value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// 默认值和来自环境变量、配置文件的值只作为未出现时的取值, 不作为计数起点
+	base := 0
+	if f.isSet && f.origin.Source != types.SourceEnv && f.origin.Source != types.SourceConfig {
+		base = *f.value
+	}
+
+	n := base + 1
+	if value != "" {
+		v, err := strconv.ParseInt(value, 10, IntSize)
+		if err != nil {
+			return fmt.Errorf("parse count '%s' for '%s': %w", value, f.Name(), err)
+		}
+		n = int(v)
+	}
+
+	// 验证（如果设置了验证器）
+	if f.validator != nil {
+		if err := f.validator(n); err != nil {
+			return err
+		}
+	}
+
+	// 设置值并标记为已设置
+	*f.value = n
+	f.isSet = true
+
+	return nil
+}
+
 // Int64Flag 64位整数标志
 //
 // Int64Flag 用于处理64位整数类型的命令行参数。
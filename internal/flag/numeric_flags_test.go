@@ -1,7 +1,10 @@
 package flag
 
 import (
+	"fmt"
 	"testing"
+
+	"gitee.com/MM-Q/qflag/internal/types"
 )
 
 // TestInt64Flag 测试64位整数标志
@@ -299,3 +302,63 @@ func TestUint64Flag(t *testing.T) {
 		t.Errorf("Expected type 'uint64', got '%s'", flag.Type().String())
 	}
 }
+
+// TestCountFlag 测试计数标志
+func TestCountFlag(t *testing.T) {
+	flag := NewCountFlag("verbose", "v", "详细级别", 0)
+
+	// 测试空值计数加一
+	for i := 1; i <= 3; i++ {
+		if err := flag.Set(""); err != nil {
+			t.Fatalf("Unexpected error incrementing: %v", err)
+		}
+		if flag.Get() != i {
+			t.Errorf("Expected %d, got %d", i, flag.Get())
+		}
+	}
+
+	// 测试显式设置计数
+	if err := flag.Set("5"); err != nil {
+		t.Errorf("Unexpected error setting explicit count: %v", err)
+	}
+	if flag.Get() != 5 {
+		t.Errorf("Expected 5, got %d", flag.Get())
+	}
+
+	// 测试无效值
+	if err := flag.Set("abc"); err == nil {
+		t.Error("Expected error for invalid count")
+	}
+
+	// 测试验证器作用于加一后的值
+	flag.Reset()
+	flag.SetValidator(func(v int) error {
+		if v > 2 {
+			return fmt.Errorf("count %d exceeds 2", v)
+		}
+		return nil
+	})
+	_ = flag.Set("")
+	_ = flag.Set("")
+	if err := flag.Set(""); err == nil {
+		t.Error("Expected validator error on third increment")
+	}
+	if flag.Get() != 2 {
+		t.Errorf("Expected value unchanged at 2, got %d", flag.Get())
+	}
+
+	// 测试非零默认值和环境变量的值不作为计数起点
+	leveled := NewCountFlag("level", "l", "级别", 2)
+	if err := leveled.Set(""); err != nil || leveled.Get() != 1 {
+		t.Errorf("Expected count to start from 0 with default 2, got %d (err: %v)", leveled.Get(), err)
+	}
+	_ = leveled.Set("4")
+	leveled.SetOrigin(types.ValueOrigin{Source: types.SourceEnv, Raw: "4", Location: "APP_LEVEL"})
+	if err := leveled.Set(""); err != nil || leveled.Get() != 1 {
+		t.Errorf("Expected count to start from 0 after env value, got %d (err: %v)", leveled.Get(), err)
+	}
+
+	if flag.Type().String() != "count" {
+		t.Errorf("Expected type 'count', got '%s'", flag.Type().String())
+	}
+}
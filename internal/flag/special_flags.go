@@ -2,6 +2,7 @@ package flag
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"gitee.com/MM-Q/qflag/internal/types"
@@ -21,6 +22,8 @@ type EnumFlag struct {
 	*BaseFlag[string]
 	// 用于快速查找的映射表
 	allowedMap map[string]bool
+	// 按声明顺序保存的允许值列表 (已去重)
+	allowedValues []string
 }
 
 // NewEnumFlag 创建枚举标志
@@ -48,11 +51,15 @@ func NewEnumFlag(longName, shortName, desc, default_ string, allowedValues []str
 
 	// 创建映射表用于快速查找
 	allowedMap := make(map[string]bool, len(allowedValues))
+	ordered := make([]string, 0, len(allowedValues))
 	for _, value := range allowedValues {
 		// 不允许空字符串作为枚举值
 		if value == "" {
 			panic(fmt.Sprintf("empty enum value in allowed values for '%s'", longName))
 		}
+		if !allowedMap[value] {
+			ordered = append(ordered, value)
+		}
 		allowedMap[value] = true
 	}
 
@@ -62,8 +69,9 @@ func NewEnumFlag(longName, shortName, desc, default_ string, allowedValues []str
 	}
 
 	return &EnumFlag{
-		BaseFlag:   NewBaseFlag(types.FlagTypeEnum, longName, shortName, desc, default_),
-		allowedMap: allowedMap,
+		BaseFlag:      NewBaseFlag(types.FlagTypeEnum, longName, shortName, desc, default_),
+		allowedMap:    allowedMap,
+		allowedValues: ordered,
 	}
 }
 
@@ -109,13 +117,27 @@ func (f *EnumFlag) Set(value string) error {
 	return nil
 }
 
+// SetImplicitValue 设置枚举标志的隐式值
+//
+// 参数:
+//   - value: 标志不带值出现时使用的值
+//
+// 功能说明:
+//   - 设置后标志可以不带值出现, 如 --color 等同于 --color=always
+//   - 显式的值必须使用 --name=value 或 -svalue 形式, 下一个参数不会被当作标志值
+//   - 隐式值在使用时经过与普通值相同的解析和验证
+func (f *EnumFlag) SetImplicitValue(value string) {
+	f.setImplicitValue(value)
+}
+
 // GetAllowedValues 获取允许的枚举值
 //
 // 返回值:
 //   - []string: 允许的枚举值列表
 //
 // 注意事项:
-//   - 返回的切片顺序可能不一致, 因为基于map的key生成
+//   - 按声明顺序返回, 重复的值只保留第一次出现
+//   - 返回的是副本, 修改不影响标志
 //   - 此方法是线程安全的
 func (f *EnumFlag) GetAllowedValues() []string {
 	f.mu.RLock()
@@ -130,14 +152,9 @@ func (f *EnumFlag) GetAllowedValues() []string {
 //
 // 注意事项:
 //   - 这是内部方法, 调用者需要自己处理线程安全
-//   - 返回的切片顺序可能不一致, 因为基于map的key生成
+//   - 按声明顺序返回副本, 帮助信息、错误消息和补全脚本的输出保持稳定
 func (f *EnumFlag) getAllowedValues() []string {
-	// 从map的key生成切片
-	result := make([]string, 0, len(f.allowedMap))
-	for value := range f.allowedMap {
-		result = append(result, value)
-	}
-	return result
+	return slices.Clone(f.allowedValues)
 }
 
 // IsAllowed 检查值是否在允许的枚举值中
@@ -164,7 +181,7 @@ func (f *EnumFlag) IsAllowed(value string) bool {
 //
 // 功能说明:
 //   - 实现 Flag 接口的 EnumValues 方法
-//   - 返回所有允许的枚举值
+//   - 按声明顺序返回所有允许的枚举值
 //   - 此方法是线程安全的
 func (f *EnumFlag) EnumValues() []string {
 	return f.GetAllowedValues()
@@ -1,6 +1,7 @@
 package flag
 
 import (
+	"slices"
 	"testing"
 	"time"
 )
@@ -34,22 +35,21 @@ func TestEnumFlag(t *testing.T) {
 		t.Error("Expected error for invalid enum value")
 	}
 
-	// 测试枚举值列表
+	// 测试枚举值列表按声明顺序返回
 	enumOptions := flag.EnumValues()
-	if len(enumOptions) != len(options) {
-		t.Errorf("Expected %d enum options, got %d", len(options), len(enumOptions))
+	if !slices.Equal(enumOptions, options) {
+		t.Errorf("Expected enum options %v in declaration order, got %v", options, enumOptions)
 	}
 
-	// 检查所有选项都存在, 不检查顺序
-	optionMap := make(map[string]bool)
-	for _, option := range options {
-		optionMap[option] = true
+	// 重复的允许值只保留第一次出现, 返回副本不影响标志
+	dup := NewEnumFlag("level", "", "级别", "b", []string{"b", "a", "b", "c"})
+	values := dup.GetAllowedValues()
+	if !slices.Equal(values, []string{"b", "a", "c"}) {
+		t.Errorf("Expected [b a c], got %v", values)
 	}
-
-	for _, enumOption := range enumOptions {
-		if !optionMap[enumOption] {
-			t.Errorf("Unexpected enum option '%s'", enumOption)
-		}
+	values[0] = "x"
+	if got := dup.EnumValues(); got[0] != "b" {
+		t.Errorf("Expected copy of allowed values, got %v", got)
 	}
 
 	// 测试基本属性
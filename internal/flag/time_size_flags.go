@@ -87,6 +87,19 @@ func (f *DurationFlag) Set(value string) error {
 	return nil
 }
 
+// SetImplicitValue 设置持续时间标志的隐式值
+//
+// 参数:
+//   - value: 标志不带值出现时使用的值
+//
+// 功能说明:
+//   - 设置后标志可以不带值出现, 如 --wait 等同于 --wait=30s
+//   - 显式的值必须使用 --name=value 或 -svalue 形式, 下一个参数不会被当作标志值
+//   - 隐式值在使用时经过与普通值相同的解析和验证
+func (f *DurationFlag) SetImplicitValue(value time.Duration) {
+	f.setImplicitValue(value.String())
+}
+
 // TimeFlag 时间标志
 //
 // TimeFlag 用于处理时间类型的命令行参数。
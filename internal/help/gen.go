@@ -34,9 +34,15 @@ func GenHelp(cmd types.Command) string {
 	// 写入命令使用方法
 	writeUsage(cmd, cfg, &buf)
 
+	// 写入命令位置参数
+	writeArguments(cmd, cfg, &buf)
+
 	// 写入命令选项
 	writeOptions(cmd, cfg, &buf)
 
+	// 写入继承的全局选项
+	writeGlobalOptions(cmd, cfg, &buf)
+
 	// 写入命令子命令
 	writeSubCmds(cmd, cfg, &buf)
 
@@ -119,7 +125,83 @@ func writeUsage(cmd types.Command, cfg *types.CmdConfig, buf *strings.Builder) {
 	}
 
 	// 没有指定时默认生成
-	fmt.Fprintf(buf, "%s%s [options] [args...]\n", types.HelpPrefix, cmd.Path())
+	fmt.Fprintf(buf, "%s%s [options] %s\n", types.HelpPrefix, cmd.Path(), usageArgs(cmd.ArgSpecs()))
+}
+
+// usageArgs 生成用法行中的位置参数部分
+//
+// 参数:
+//   - specs: 位置参数声明列表
+//
+// 返回值:
+//   - string: 位置参数用法, 未声明时为 [args...]
+//
+// 格式说明:
+//   - 必需参数: <name>, 可选参数: [name]
+//   - 必需可变参数: <name>..., 可选可变参数: [name...]
+func usageArgs(specs []types.ArgSpec) string {
+	if len(specs) == 0 {
+		return "[args...]"
+	}
+
+	parts := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		switch {
+		case spec.Required && spec.Variadic:
+			parts = append(parts, "<"+spec.Name+">...")
+		case spec.Required:
+			parts = append(parts, "<"+spec.Name+">")
+		case spec.Variadic:
+			parts = append(parts, "["+spec.Name+"...]")
+		default:
+			parts = append(parts, "["+spec.Name+"]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// writeArguments 写入命令位置参数
+//
+// 参数:
+//   - cmd: 要生成帮助信息的命令
+//   - cfg: 命令配置
+//   - buf: 用于写入帮助信息的字符串构建器
+func writeArguments(cmd types.Command, cfg *types.CmdConfig, buf *strings.Builder) {
+	specs := cmd.ArgSpecs()
+	if len(specs) == 0 {
+		return
+	}
+
+	if cfg.UseChinese {
+		buf.WriteString(types.HelpArgumentsCN)
+	} else {
+		buf.WriteString(types.HelpArgumentsEN)
+	}
+
+	// 收集参数信息, 保持声明顺序
+	options := make([]types.OptionInfo, 0, len(specs))
+	for _, spec := range specs {
+		opt := types.OptionInfo{
+			NamePart: fmt.Sprintf("%s <%s>", spec.Name, spec.Value.Type().String()),
+			Desc:     spec.Desc,
+		}
+		if !spec.Required && !spec.Variadic {
+			opt.DefValue = utils.FormatDefaultValue(spec.Value.Type(), spec.Value.GetDef())
+		}
+		options = append(options, opt)
+	}
+
+	// 计算参数名称最大宽度
+	maxWidth := utils.CalcOptionMaxWidth(options)
+
+	// 写入参数
+	for _, opt := range options {
+		fmt.Fprintf(buf, "  %-*s%s%s", maxWidth, opt.NamePart, types.HelpOptionSubCmdSpace, opt.Desc)
+		if opt.DefValue != "" {
+			fmt.Fprintf(buf, " (default: %s)", opt.DefValue)
+		}
+		buf.WriteByte('\n')
+	}
 }
 
 // writeOptions 写入命令选项
@@ -140,6 +222,36 @@ func writeOptions(cmd types.Command, cfg *types.CmdConfig, buf *strings.Builder)
 		buf.WriteString(types.HelpOptionsEN)
 	}
 
+	writeFlagList(flags, buf)
+}
+
+// writeGlobalOptions 写入从祖先命令继承的全局选项
+//
+// 参数:
+//   - cmd: 要生成帮助信息的命令
+//   - cfg: 命令配置
+//   - buf: 用于写入帮助信息的字符串构建器
+func writeGlobalOptions(cmd types.Command, cfg *types.CmdConfig, buf *strings.Builder) {
+	flags := cmd.InheritedFlags()
+	if len(flags) == 0 {
+		return
+	}
+
+	if cfg.UseChinese {
+		buf.WriteString(types.HelpGlobalOptionsCN)
+	} else {
+		buf.WriteString(types.HelpGlobalOptionsEN)
+	}
+
+	writeFlagList(flags, buf)
+}
+
+// writeFlagList 写入标志列表
+//
+// 参数:
+//   - flags: 要写入的标志列表
+//   - buf: 用于写入帮助信息的字符串构建器
+func writeFlagList(flags []types.Flag, buf *strings.Builder) {
 	// 收集命令选项
 	options := make([]types.OptionInfo, 0, len(flags))
 	for _, f := range flags {
@@ -148,12 +260,24 @@ func writeOptions(cmd types.Command, cfg *types.CmdConfig, buf *strings.Builder)
 			DefValue: utils.FormatDefaultValue(f.Type(), f.GetDef()),
 		}
 
+		// 支持取反的布尔标志显示为 --[no-]name
+		longName := f.LongName()
+		if f.IsNegatable() {
+			longName = "[no-]" + longName
+		}
+
+		// 设置了隐式值的标志显示为 --name[=<type>]
+		valuePart := fmt.Sprintf(" <%s>", f.Type().String())
+		if _, ok := f.ImplicitValue(); ok {
+			valuePart = fmt.Sprintf("[=<%s>]", f.Type().String())
+		}
+
 		if f.LongName() != "" && f.ShortName() != "" {
-			opt.NamePart = fmt.Sprintf("-%s, --%s <%s>", f.ShortName(), f.LongName(), f.Type().String())
+			opt.NamePart = fmt.Sprintf("-%s, --%s%s", f.ShortName(), longName, valuePart)
 		} else if f.LongName() != "" {
-			opt.NamePart = fmt.Sprintf("--%s <%s>", f.LongName(), f.Type().String())
+			opt.NamePart = fmt.Sprintf("--%s%s", longName, valuePart)
 		} else if f.ShortName() != "" {
-			opt.NamePart = fmt.Sprintf("-%s <%s>", f.ShortName(), f.Type().String())
+			opt.NamePart = fmt.Sprintf("-%s%s", f.ShortName(), valuePart)
 		}
 
 		options = append(options, opt)
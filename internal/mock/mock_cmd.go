@@ -1,6 +1,10 @@
 package mock
 
 import (
+	"context"
+	"io"
+	"os"
+
 	"gitee.com/MM-Q/qflag/internal/types"
 )
 
@@ -80,6 +84,11 @@ func (c *MockCommandBasic) Flags() []types.Flag {
 	return c.flagRegistry.List()
 }
 
+func (c *MockCommandBasic) ArgSpecs() []types.ArgSpec { return []types.ArgSpec{} }
+
+func (c *MockCommandBasic) PersistentFlags() []types.Flag { return []types.Flag{} }
+func (c *MockCommandBasic) InheritedFlags() []types.Flag  { return []types.Flag{} }
+
 func (c *MockCommandBasic) FlagRegistry() types.FlagRegistry {
 	return c.flagRegistry
 }
@@ -117,6 +126,10 @@ func (c *MockCommandBasic) IsRootCmd() bool {
 	return true // 默认为根命令
 }
 
+func (c *MockCommandBasic) Parent() types.Command {
+	return nil // 默认为根命令
+}
+
 func (c *MockCommandBasic) Path() string {
 	return c.name
 }
@@ -188,10 +201,34 @@ func (c *MockCommandBasic) Help() string {
 	return "Mock command help"
 }
 
+func (c *MockCommandBasic) Context() context.Context {
+	return context.Background()
+}
+
+func (c *MockCommandBasic) Hook(t types.HookType) func(types.Command) error {
+	return nil
+}
+
+func (c *MockCommandBasic) Middlewares() []types.Middleware {
+	return nil
+}
+
 func (c *MockCommandBasic) PrintHelp() {
 	// 模拟打印帮助
 }
 
+func (c *MockCommandBasic) Out() io.Writer {
+	return os.Stdout
+}
+
+func (c *MockCommandBasic) ErrOut() io.Writer {
+	return os.Stderr
+}
+
+func (c *MockCommandBasic) In() io.Reader {
+	return os.Stdin
+}
+
 func (c *MockCommandBasic) SetParser(p types.Parser) {
 	// 模拟设置解析器
 }
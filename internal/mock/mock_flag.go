@@ -129,8 +129,18 @@ func (f *MockFlag) AutoBindEnv() {
 }
 
 func (f *MockFlag) EnumValues() []string { return f.enumValues }
+func (f *MockFlag) IsNegatable() bool    { return false }
 func (f *MockFlag) Default() string      { return formatValue(f.value) }
 
+func (f *MockFlag) ImplicitValue() (string, bool)        { return "", false }
+func (f *MockFlag) CompletionFunc() types.CompletionFunc { return nil }
+func (f *MockFlag) ValueHint() types.ValueHint           { return types.ValueHint{} }
+
+func (f *MockFlag) Origin() types.ValueOrigin {
+	return types.ValueOrigin{Source: types.SourceDefault, Raw: f.Default()}
+}
+func (f *MockFlag) SetOrigin(origin types.ValueOrigin) {}
+
 func (f *MockFlag) IsRequired() bool { return f.isRequired }
 func (f *MockFlag) IsHidden() bool   { return f.isHidden }
 
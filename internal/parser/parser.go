@@ -1,30 +1,34 @@
 package parser
 
 import (
-	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"gitee.com/MM-Q/qflag/internal/builtin"
 	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
 )
 
 // DefaultParser 默认解析器实现
 //
-// DefaultParser 是types.Parser接口的默认实现, 基于Go标准库的flag包。
+// DefaultParser 是types.Parser接口的默认实现, 使用内置的 GNU/POSIX 风格参数扫描器。
 // 它负责解析命令行参数、处理环境变量和路由子命令。
 //
 // 特性:
 //   - 支持所有标准标志类型
+//   - 支持组合短标志 (-vxf file) 和附加值 (-ofile, -p8080)
 //   - 支持环境变量绑定
 //   - 支持子命令解析和路由
 //   - 支持内置标志自动处理
 type DefaultParser struct {
-	flagSet          *flag.FlagSet               // 标准库flag.FlagSet实例
+	args             []string                    // 解析后剩余的位置参数
 	errorHandling    types.ErrorHandling         // 错误处理策略
 	builtinMgr       *builtin.BuiltinFlagManager // 内置标志管理器
 	setFlagsMap      map[string]bool             // 已设置标志映射（缓存）
 	flagDisplayNames map[string]string           // 所有标志的显示名称映射（缓存）
+	collectErrors    bool                        // 是否收集所有错误 (当前解析)
+	errs             []error                     // 收集模式下已收集的错误
 }
 
 // NewDefaultParser 创建默认解析器实例
@@ -53,12 +57,16 @@ func NewDefaultParser(errorHandling types.ErrorHandling) types.Parser {
 // 注意事项:
 //   - 重置所有标志到默认状态（避免重复解析时的遗留值）
 //   - 注册内置标志
-//   - 创建新的FlagSet实例进行解析
-//   - 注册命令的所有标志到FlagSet
+//   - 开启响应文件时, 将 @file 参数展开为文件中的参数列表
+//   - 预扫描未知标志, 返回带建议的错误
 //   - 先解析命令行参数
 //   - 再加载环境变量 (仅在标志未被命令行参数设置时)
-//   - 处理内置标志
+//   - 然后加载配置文件 (仅在标志未被命令行参数和环境变量设置时)
+//   - 开启错误收集模式时, 以上步骤和组验证中的错误被收集后汇总为 types.ParseErrors 返回
+//   - 处理内置标志, 请求帮助、版本或补全时返回 types.ErrHelp 等哨兵错误, 不退出程序
+//   - 按声明绑定位置参数, 检查参数个数和类型
 //   - 不处理子命令路由
+//   - 响应文件、命令行参数、环境变量、配置文件、组验证和位置参数的错误都按错误处理策略处理 (见 handleParseError)
 //   - 使用defer确保命令状态和参数在函数返回时被设置
 func (p *DefaultParser) ParseOnly(cmd types.Command, args []string) error {
 	// 如果禁用标志解析，直接设置参数并返回
@@ -68,12 +76,10 @@ func (p *DefaultParser) ParseOnly(cmd types.Command, args []string) error {
 		return nil
 	}
 
-	// 创建新的 FlagSet 实例
-	p.flagSet = flag.NewFlagSet("", p.errorHandling)
-
-	// 自定义 Usage 函数, 避免打印默认的使用说明
-	p.flagSet.Usage = func() {
-		cmd.PrintHelp()
+	// 获取命令配置, 检查是否为nil
+	config := cmd.Config()
+	if config == nil {
+		return fmt.Errorf("nil config in '%s'", cmd.Name())
 	}
 
 	// 重置所有标志到默认状态
@@ -85,6 +91,7 @@ func (p *DefaultParser) ParseOnly(cmd types.Command, args []string) error {
 	for _, f := range flagRegistry.List() {
 		f.Reset()
 	}
+	resetArgs(cmd)
 
 	// 注册内置标志
 	if err := p.builtinMgr.RegisterBuiltinFlags(cmd); err != nil {
@@ -92,30 +99,73 @@ func (p *DefaultParser) ParseOnly(cmd types.Command, args []string) error {
 	}
 
 	// 使用defer确保命令状态和参数在函数返回时被设置
+	p.args = args
 	defer func() {
 		cmd.SetParsed(true)
-		cmd.SetArgs(p.flagSet.Args())
+		cmd.SetArgs(p.args)
 	}()
 
-	// 注册命令行标志
-	for _, f := range flagRegistry.List() {
-		p.registerFlag(f)
+	// 解析阶段的错误 (响应文件、命令行参数、环境变量、配置文件、组验证) 统一按错误处理策略处理
+	if err := p.parseFlags(cmd, args, config); err != nil {
+		return p.handleParseError(cmd, err)
+	}
+
+	// 处理内置标志
+	if err := p.builtinMgr.HandleBuiltinFlags(cmd); err != nil {
+		return err
+	}
+
+	// 绑定声明的位置参数
+	if err := bindArgs(cmd, p.args); err != nil {
+		return p.handleParseError(cmd, err)
+	}
+
+	return nil
+}
+
+// parseFlags 执行解析阶段: 展开响应文件、解析命令行参数、加载环境变量和配置文件、验证组规则
+//
+// 参数:
+//   - cmd: 要解析的命令
+//   - args: 命令行参数列表
+//   - config: 命令配置
+//
+// 返回值:
+//   - error: 解析失败时返回错误, 错误收集模式下返回汇总的 types.ParseErrors
+//
+// 注意事项:
+//   - 不处理错误处理策略, 由 ParseOnly 统一交给 handleParseError
+//   - 剩余的位置参数保存在 p.args 中
+func (p *DefaultParser) parseFlags(cmd types.Command, args []string, config *types.CmdConfig) error {
+	// 展开响应文件 (在扫描标志之前进行)
+	if utils.ResponseFilesEnabled(cmd) {
+		expanded, err := expandResponseFiles(cmd, args)
+		if err != nil {
+			return err
+		}
+		args = expanded
+		p.args = args
 	}
 
+	// 初始化错误收集状态
+	p.collectErrors = utils.CollectErrorsEnabled(cmd)
+	p.errs = nil
+
 	// 预检查：扫描未知标志
-	if err := checkUnknownFlags(cmd, args); err != nil {
+	if err := checkUnknownFlags(cmd, args, config.Interspersed, p.report); err != nil {
 		return err
 	}
 
 	// 先解析命令行参数
-	if err := p.flagSet.Parse(args); err != nil {
+	remaining, err := p.parseArgs(cmd, args, config.Interspersed)
+	p.args = remaining
+	if err != nil {
 		return err
 	}
 
-	// 获取命令配置, 检查是否为nil
-	config := cmd.Config()
-	if config == nil {
-		return fmt.Errorf("nil config in '%s'", cmd.Name())
+	// 验证累积模式标志合并后的完整值
+	if err := p.validateAccumulated(cmd); err != nil {
+		return err
 	}
 
 	// 加载环境变量 (仅在标志未被命令行参数设置时)
@@ -123,30 +173,36 @@ func (p *DefaultParser) ParseOnly(cmd types.Command, args []string) error {
 		return err
 	}
 
+	// 加载配置文件 (仅在标志未被命令行参数和环境变量设置时)
+	if err := p.loadConfigFile(cmd); err != nil {
+		return err
+	}
+
 	// 如果有互斥组或必需组或标志依赖关系，需要验证, 则构建已设置标志映射
 	if len(config.MutexGroups) > 0 || len(config.RequiredGroups) > 0 || len(config.FlagDependencies) > 0 {
 		// 构建已设置标志映射（在验证前构建，确保标志状态已确定）
 		p.buildSetFlagsMap(cmd)
 
 		// 验证互斥组规则
-		if err := p.validateMutexGroups(config); err != nil {
+		if err := p.validateMutexGroups(cmd, config); err != nil {
 			return err
 		}
 
 		// 验证必需组规则
-		if err := p.validateRequiredGroups(config); err != nil {
+		if err := p.validateRequiredGroups(cmd, config); err != nil {
 			return err
 		}
 
 		// 验证标志依赖关系
-		if err := p.validateFlagDependencies(config); err != nil {
+		if err := p.validateFlagDependencies(cmd, config); err != nil {
 			return err
 		}
 	}
 
-	// 处理内置标志
-	if err := p.builtinMgr.HandleBuiltinFlags(cmd); err != nil {
-		return err
+	// 收集模式下存在错误时, 一并检查位置参数后汇总返回
+	if len(p.errs) > 0 {
+		_ = p.report(bindArgs(cmd, p.args))
+		return &types.ParseErrors{Command: cmd.Name(), Errors: p.errs}
 	}
 
 	return nil
@@ -163,7 +219,7 @@ func (p *DefaultParser) ParseOnly(cmd types.Command, args []string) error {
 //
 // 注意事项:
 //   - 首先调用ParseOnly解析参数
-//   - 检查剩余参数是否为子命令
+//   - 检查剩余参数是否为子命令 (开启缩写模式时支持唯一前缀)
 //   - 如果是子命令, 递归解析子命令
 //   - 不执行子命令的运行函数
 func (p *DefaultParser) Parse(cmd types.Command, args []string) error {
@@ -173,7 +229,6 @@ func (p *DefaultParser) Parse(cmd types.Command, args []string) error {
 	}
 
 	// 检查剩余参数是否为子命令
-	cmdRegistry := cmd.CmdRegistry()
 	remainingArgs := cmd.Args()
 
 	// 如果有剩余参数, 检查是否为子命令
@@ -182,7 +237,11 @@ func (p *DefaultParser) Parse(cmd types.Command, args []string) error {
 		firstArg := remainingArgs[0]
 
 		// 检查是否为子命令, 如果是, 递归解析并执行子命令
-		if subCmd, ok := cmdRegistry.Get(firstArg); ok {
+		subCmd, err := resolveSubCmd(cmd, firstArg)
+		if err != nil {
+			return err
+		}
+		if subCmd != nil {
 			return subCmd.Parse(remainingArgs[1:])
 		}
 
@@ -212,18 +271,21 @@ func (p *DefaultParser) Parse(cmd types.Command, args []string) error {
 //
 // 注意事项:
 //   - 首先调用ParseOnly解析参数
-//   - 检查剩余参数是否为子命令
+//   - 检查剩余参数是否为子命令 (开启缩写模式时支持唯一前缀)
 //   - 如果是子命令, 递归解析并执行子命令
 //   - 如果不是子命令, 执行当前命令的运行函数
-//   - 如果命令没有设置运行函数, 返回错误
+//   - 运行函数前后按顺序执行路由路径上的生命周期钩子 (PersistentPreRun、PreRun、PostRun、PersistentPostRun)
+//   - 如果命令没有设置运行函数, 返回错误, 不执行钩子
 func (p *DefaultParser) ParseAndRoute(cmd types.Command, args []string) error {
+	// 记录路由到的命令, Execute 按该命令的设置映射退出码
+	utils.MarkRouted(cmd)
+
 	// 先解析参数 (ParseOnly 会处理禁用标志解析的情况)
 	if err := p.ParseOnly(cmd, args); err != nil {
 		return err
 	}
 
 	// 检查剩余参数是否为子命令
-	cmdRegistry := cmd.CmdRegistry()
 	remainingArgs := cmd.Args()
 
 	// 如果是子命令, 递归解析并执行子命令
@@ -231,7 +293,11 @@ func (p *DefaultParser) ParseAndRoute(cmd types.Command, args []string) error {
 		firstArg := remainingArgs[0] // 获取第一个参数
 
 		// 检查是否为子命令, 如果是, 递归解析并执行子命令
-		if subCmd, ok := cmdRegistry.Get(firstArg); ok {
+		subCmd, err := resolveSubCmd(cmd, firstArg)
+		if err != nil {
+			return err
+		}
+		if subCmd != nil {
 			return subCmd.ParseAndRoute(remainingArgs[1:])
 		}
 
@@ -247,10 +313,57 @@ func (p *DefaultParser) ParseAndRoute(cmd types.Command, args []string) error {
 		// 没有子命令或参数以 - 开头 → 是普通参数，正常处理
 	}
 
-	// 如果不是子命令, 执行当前命令的运行函数
+	// 如果不是子命令, 按顺序执行生命周期钩子和当前命令的运行函数
 	if cmd.HasRunFunc() {
-		return cmd.Run()
+		return runWithHooks(cmd)
+	}
+
+	return &types.NoRunFuncError{Command: cmd.Name(), Path: cmd.Path()}
+}
+
+// report 报告解析过程中发现的错误
+//
+// 参数:
+//   - err: 发现的错误, 为nil时忽略
+//
+// 返回值:
+//   - error: 收集模式下记录错误并返回nil, 以便继续解析; 否则原样返回错误
+func (p *DefaultParser) report(err error) error {
+	if err == nil || !p.collectErrors {
+		return err
+	}
+	p.errs = append(p.errs, err)
+	return nil
+}
+
+// handleParseError 按错误处理策略处理参数解析错误
+//
+// 参数:
+//   - cmd: 当前命令
+//   - err: 解析错误
+//
+// 返回值:
+//   - error: ContinueOnError 策略下返回原错误
+//
+// 注意事项:
+//   - 与标准库 flag 包行为一致: 先输出错误信息和帮助信息
+//   - ExitOnError 策略下按错误类型映射的退出码退出程序 (见 utils.ExitCode), 调用方式错误为 2
+//   - PanicOnError 策略下触发 panic
+//   - 在 Execute 中执行时直接返回错误, 由 Execute 统一输出和退出
+func (p *DefaultParser) handleParseError(cmd types.Command, err error) error {
+	if utils.Executing(cmd) {
+		return err
+	}
+
+	fmt.Fprintln(cmd.ErrOut(), err)
+	cmd.PrintHelp()
+
+	switch p.errorHandling {
+	case types.ExitOnError:
+		os.Exit(utils.ExitCode(cmd, err))
+	case types.PanicOnError:
+		panic(err)
 	}
 
-	return fmt.Errorf("cmd %q has no run function set", cmd.Name())
+	return err
 }
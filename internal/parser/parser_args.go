@@ -0,0 +1,86 @@
+// parser_args.go - 位置参数绑定
+//
+// 该文件实现将剩余位置参数按声明绑定到 ArgSpec 值容器的功能
+
+package parser
+
+import (
+	"fmt"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// bindArgs 将位置参数绑定到命令声明的位置参数
+//
+// 参数:
+//   - cmd: 当前命令
+//   - args: 解析标志后剩余的位置参数
+//
+// 返回值:
+//   - error: 参数个数不符或参数值无效时返回错误
+//
+// 注意事项:
+//   - 命令未声明位置参数时不做任何检查
+//   - 第一个位置参数是子命令名时跳过绑定, 交由子命令处理
+//   - 可变参数接收剩余的所有位置参数, 每个参数作为一个元素
+//   - 没有可变参数时, 多余的位置参数视为错误
+func bindArgs(cmd types.Command, args []string) error {
+	specs := cmd.ArgSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	if len(args) > 0 && isSubCmdName(cmd, args[0]) {
+		return nil
+	}
+
+	for i, spec := range specs {
+		if spec.Variadic {
+			items := args[min(i, len(args)):]
+			if len(items) == 0 {
+				if spec.Required {
+					return &types.MissingArgumentError{Command: cmd.Name(), Path: cmd.Path(), Arg: spec.Name}
+				}
+				return nil
+			}
+
+			setter, ok := spec.Value.(types.ItemSetter)
+			if !ok {
+				return fmt.Errorf("variadic argument <%s> requires a slice value in '%s'", spec.Name, cmd.Name())
+			}
+			if err := setter.SetItems(items); err != nil {
+				return &types.InvalidArgumentError{Command: cmd.Name(), Path: cmd.Path(), Arg: spec.Name, Values: items, Variadic: true, Allowed: spec.Value.EnumValues(), Err: err}
+			}
+			return nil
+		}
+
+		if i >= len(args) {
+			if spec.Required {
+				return &types.MissingArgumentError{Command: cmd.Name(), Path: cmd.Path(), Arg: spec.Name}
+			}
+			continue
+		}
+
+		if err := spec.Value.Set(args[i]); err != nil {
+			return &types.InvalidArgumentError{Command: cmd.Name(), Path: cmd.Path(), Arg: spec.Name, Values: []string{args[i]}, Allowed: spec.Value.EnumValues(), Err: err}
+		}
+	}
+
+	if len(args) > len(specs) {
+		return &types.TooManyArgumentsError{Command: cmd.Name(), Path: cmd.Path(), Max: len(specs), Got: len(args)}
+	}
+
+	return nil
+}
+
+// resetArgs 重置命令声明的位置参数值
+//
+// 参数:
+//   - cmd: 当前命令
+func resetArgs(cmd types.Command) {
+	for _, spec := range cmd.ArgSpecs() {
+		if spec.Value != nil {
+			spec.Value.Reset()
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// parser_config.go - 配置文件加载
+//
+// 该文件实现从配置文件加载标志值的功能, 优先级低于命令行参数和环境变量
+
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/config"
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// loadConfigFile 从配置文件加载命令的标志值
+//
+// 参数:
+//   - cmd: 要加载配置的命令
+//
+// 返回值:
+//   - error: 配置文件格式错误、存在未知键或值无效时返回错误
+//
+// 注意事项:
+//   - 使用命令自身或最近的祖先命令设置的配置文件
+//   - 子命令的值位于按命令路径逐级嵌套的分区中, 分区不存在时跳过
+//   - 分区中的键可以是命令自身的标志, 也可以是从祖先命令继承的持久标志
+//   - 只加载未被命令行参数和环境变量设置的标志
+//   - 记录来源为配置文件, 来源位置为 "文件路径:键路径"
+//   - 配置文件不存在时跳过加载
+//   - 错误收集模式下报告每个未知键和无效值后继续加载
+func (p *DefaultParser) loadConfigFile(cmd types.Command) error {
+	owner, section := configOwner(cmd)
+	if owner == nil {
+		return nil
+	}
+	cfg := owner.Config()
+
+	values, err := config.Load(cfg.ConfigFile, cfg.ConfigDecoder)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return p.report(fmt.Errorf("load config failed in '%s': %w", cmd.Name(), err))
+	}
+
+	// 定位当前命令的分区
+	table := values
+	for _, name := range section {
+		sub, ok := table[name].(map[string]any)
+		if !ok {
+			return nil
+		}
+		table = sub
+	}
+
+	// 按键名排序, 保证错误信息稳定
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		raw := table[key]
+
+		f := lookupConfigFlag(cmd, key)
+		if f == nil {
+			// 子命令分区由子命令自身加载
+			if _, isTable := raw.(map[string]any); isTable && cmd.HasSubCmd(key) {
+				continue
+			}
+			keyErr := &types.UnknownConfigKeyError{Command: cmd.Name(), Path: cmd.Path(), File: cfg.ConfigFile, Key: configKeyPath(section, key)}
+			if err := p.report(keyErr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// 命令行参数和环境变量优先; 继承的持久标志可以覆盖祖先命令分区中的值
+		if f.IsSet() && f.Origin().Source != types.SourceConfig {
+			continue
+		}
+
+		location := cfg.ConfigFile + ":" + configKeyPath(section, key)
+		value, err := setConfigValue(f, raw)
+		if err != nil {
+			if err := p.report(newInvalidValueError(cmd, f, value, types.SourceConfig, location, err)); err != nil {
+				return err
+			}
+			continue
+		}
+		f.SetOrigin(types.ValueOrigin{Source: types.SourceConfig, Raw: value, Location: location})
+	}
+
+	return nil
+}
+
+// lookupConfigFlag 查找配置键对应的标志
+//
+// 参数:
+//   - cmd: 当前命令
+//   - key: 配置键, 对应标志的长名称
+//
+// 返回值:
+//   - types.Flag: 命令自身的标志优先, 其次是继承的持久标志; 未找到时返回nil
+func lookupConfigFlag(cmd types.Command, key string) types.Flag {
+	if f, ok := cmd.FlagRegistry().Get(key); ok && f.LongName() == key {
+		return f
+	}
+	for _, f := range cmd.InheritedFlags() {
+		if f.LongName() == key {
+			return f
+		}
+	}
+	return nil
+}
+
+// configOwner 查找为命令提供配置文件的命令
+//
+// 参数:
+//   - cmd: 当前命令
+//
+// 返回值:
+//   - types.Command: 设置了配置文件的命令 (自身或最近的祖先), 没有时返回nil
+//   - []string: 从该命令到当前命令的分区路径
+func configOwner(cmd types.Command) (types.Command, []string) {
+	var section []string
+	for c := cmd; c != nil; c = c.Parent() {
+		if cfg := c.Config(); cfg != nil && cfg.ConfigFile != "" {
+			return c, section
+		}
+		section = append([]string{c.Name()}, section...)
+	}
+	return nil, nil
+}
+
+// configKeyPath 拼接配置键的完整路径, 用于错误信息和来源位置
+//
+// 参数:
+//   - section: 分区路径
+//   - key: 键名
+//
+// 返回值:
+//   - string: 以 . 分隔的完整路径
+func configKeyPath(section []string, key string) string {
+	return strings.Join(append(append([]string{}, section...), key), ".")
+}
+
+// setConfigValue 将配置值设置到标志
+//
+// 参数:
+//   - f: 目标标志
+//   - raw: 解码后的配置值
+//
+// 返回值:
+//   - string: 配置值的字符串形式, 数组元素和映射键值对以逗号连接
+//   - error: 值类型与标志不匹配或值无效时返回错误
+//
+// 注意事项:
+//   - 数组逐项设置到切片标志, 元素不按逗号分割
+//   - 映射设置到映射标志
+//   - 标量格式化为字符串后通过标志的Set方法设置
+func setConfigValue(f types.Flag, raw any) (string, error) {
+	switch v := raw.(type) {
+	case []any:
+		setter, ok := f.(types.ItemSetter)
+		if !ok {
+			return "", fmt.Errorf("array value is not supported by %s flag", f.Type())
+		}
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := configScalar(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), setter.SetItems(items)
+
+	case map[string]any:
+		if f.Type() != types.FlagTypeMap {
+			return "", fmt.Errorf("table value is not supported by %s flag", f.Type())
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			s, err := configScalar(v[key])
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, key+"="+s)
+		}
+		value := strings.Join(pairs, ",")
+		return value, f.Set(value)
+
+	default:
+		s, err := configScalar(v)
+		if err != nil {
+			return "", err
+		}
+		return s, f.Set(s)
+	}
+}
+
+// configScalar 将标量配置值格式化为字符串
+//
+// 参数:
+//   - v: 配置值
+//
+// 返回值:
+//   - string: 格式化后的字符串
+//   - error: 值为数组、映射或nil时返回错误
+func configScalar(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case json.Number:
+		return x.String(), nil
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(x), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(x), nil
+	case nil:
+		return "", fmt.Errorf("null value is not supported")
+	case []any, map[string]any:
+		return "", fmt.Errorf("nested value is not supported")
+	default:
+		return fmt.Sprint(x), nil
+	}
+}
@@ -18,11 +18,12 @@ import (
 // 注意事项:
 //   - 遍历命令的所有标志
 //   - 环境变量优先级低于命令行参数
+//   - 错误收集模式下报告每个无效的环境变量值后继续加载
 func (p *DefaultParser) loadEnvVars(cmd types.Command, envPrefix string) error {
 	flags := cmd.Flags()
 
 	for _, f := range flags {
-		if err := p.loadFlagEnv(f, envPrefix); err != nil {
+		if err := p.report(p.loadFlagEnv(cmd, f, envPrefix)); err != nil {
 			return err
 		}
 	}
@@ -33,6 +34,7 @@ func (p *DefaultParser) loadEnvVars(cmd types.Command, envPrefix string) error {
 // loadFlagEnv 加载单个标志的环境变量
 //
 // 参数:
+//   - cmd: 标志所属的命令
 //   - f: 要加载环境变量的标志
 //   - envPrefix: 环境变量前缀
 //
@@ -44,8 +46,8 @@ func (p *DefaultParser) loadEnvVars(cmd types.Command, envPrefix string) error {
 //   - 环境变量名由前缀和标志的环境变量名组成
 //   - 如果环境变量不存在, 跳过加载
 //   - 只有在标志未被命令行参数设置时才加载环境变量
-//   - 环境变量值通过标志的Set方法设置
-func (p *DefaultParser) loadFlagEnv(f types.Flag, envPrefix string) error {
+//   - 环境变量值通过标志的Set方法设置, 并记录来源为环境变量
+func (p *DefaultParser) loadFlagEnv(cmd types.Command, f types.Flag, envPrefix string) error {
 	envVar := f.GetEnvVar()
 	if envVar == "" {
 		return nil
@@ -68,5 +70,9 @@ func (p *DefaultParser) loadFlagEnv(f types.Flag, envPrefix string) error {
 		return nil
 	}
 
-	return f.Set(value)
+	if err := f.Set(value); err != nil {
+		return newInvalidValueError(cmd, f, value, types.SourceEnv, fullEnvVar, err)
+	}
+	f.SetOrigin(types.ValueOrigin{Source: types.SourceEnv, Raw: value, Location: fullEnvVar})
+	return nil
 }
@@ -0,0 +1,48 @@
+// parser_errors.go - 解析错误构造
+//
+// 该文件包含构造结构化解析错误的辅助函数
+
+package parser
+
+import (
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// newInvalidValueError 创建标志值无效错误
+//
+// 参数:
+//   - cmd: 当前命令
+//   - f: 设置失败的标志
+//   - value: 无效的值
+//   - source: 值的来源
+//   - location: 来源位置 (标志写法、环境变量名或 "配置文件:键路径")
+//   - err: 底层错误
+//
+// 返回值:
+//   - error: *types.InvalidValueError 错误
+//
+// 注意事项:
+//   - 标志名称优先使用长名称, 没有长名称时使用短名称
+//   - 枚举标志会附带可选值列表
+func newInvalidValueError(cmd types.Command, f types.Flag, value string, source types.ValueSource, location string, err error) error {
+	name := f.LongName()
+	if name == "" {
+		name = f.ShortName()
+	}
+
+	var allowed []string
+	if values := f.EnumValues(); len(values) > 0 {
+		allowed = append(allowed, values...)
+	}
+
+	return &types.InvalidValueError{
+		Command:  cmd.Name(),
+		Path:     cmd.Path(),
+		Flag:     name,
+		Value:    value,
+		Source:   source,
+		Location: location,
+		Allowed:  allowed,
+		Err:      err,
+	}
+}
@@ -0,0 +1,127 @@
+// parser_hooks.go - 生命周期钩子执行
+//
+// 该文件实现路由到最终命令后按顺序执行钩子和运行函数的功能
+
+package parser
+
+import (
+	"context"
+	"errors"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// runWithHooks 执行命令的运行函数及路由路径上的生命周期钩子
+//
+// 参数:
+//   - cmd: 路由到的最终命令
+//
+// 返回值:
+//   - error: 钩子或运行函数返回的错误, 多个错误时使用 errors.Join 合并
+//
+// 执行顺序:
+//  1. 从根命令到 cmd, 依次执行每个命令的 PersistentPreRun
+//  2. cmd 的 PreRun
+//  3. cmd 的 Run, 由路由路径上的中间件包装 (见 wrapRun)
+//  4. cmd 的 PostRun
+//  5. 从 cmd 到根命令, 依次执行每个命令的 PersistentPostRun
+//
+// 注意事项:
+//   - 所有钩子接收的都是 cmd, 而不是声明钩子的祖先命令
+//   - 执行上下文在开始前已取消时直接返回取消原因 (context.Cause), 不执行任何钩子
+//   - 任一前置钩子返回错误时立即中止, 不再执行运行函数和后置钩子
+//   - 运行函数返回错误时仍执行所有后置钩子, 便于清理资源
+func runWithHooks(cmd types.Command) error {
+	if ctx := cmd.Context(); ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+
+	// 从根命令到当前命令的路由路径
+	var chain []types.Command
+	for c := cmd; c != nil; c = c.Parent() {
+		chain = append([]types.Command{c}, chain...)
+	}
+
+	// 前置钩子
+	for _, c := range chain {
+		if err := callHook(c, types.PersistentPreRunHook, cmd); err != nil {
+			return err
+		}
+	}
+	if err := callHook(cmd, types.PreRunHook, cmd); err != nil {
+		return err
+	}
+
+	// 运行函数和后置钩子, 后置钩子总是执行
+	errs := []error{wrapRun(chain)(cmd), callHook(cmd, types.PostRunHook, cmd)}
+	for i := len(chain) - 1; i >= 0; i-- {
+		errs = append(errs, callHook(chain[i], types.PersistentPostRunHook, cmd))
+	}
+
+	return joinErrors(errs)
+}
+
+// wrapRun 使用路由路径上的中间件包装最终命令的运行函数
+//
+// 参数:
+//   - chain: 从根命令到最终命令的路由路径
+//
+// 返回值:
+//   - types.RunFunc: 包装后的运行函数, 最内层调用最终命令的 Run
+//
+// 功能说明:
+//   - 根命令的中间件在最外层, 同一命令上先注册的中间件在外层
+//   - 路由路径上没有中间件时等价于直接调用 Run
+func wrapRun(chain []types.Command) types.RunFunc {
+	run := types.RunFunc(func(c types.Command) error { return c.Run() })
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		mws := chain[i].Middlewares()
+		for j := len(mws) - 1; j >= 0; j-- {
+			run = mws[j](run)
+		}
+	}
+
+	return run
+}
+
+// callHook 调用命令上指定类型的钩子
+//
+// 参数:
+//   - owner: 声明钩子的命令
+//   - t: 钩子类型
+//   - target: 实际执行的命令, 作为钩子参数
+//
+// 返回值:
+//   - error: 钩子返回的错误, 未设置钩子时返回nil
+func callHook(owner types.Command, t types.HookType, target types.Command) error {
+	if hook := owner.Hook(t); hook != nil {
+		return hook(target)
+	}
+	return nil
+}
+
+// joinErrors 合并错误列表
+//
+// 参数:
+//   - errs: 错误列表, 可以包含nil
+//
+// 返回值:
+//   - error: 没有错误时返回nil, 只有一个错误时原样返回, 否则使用 errors.Join 合并
+func joinErrors(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	default:
+		return errors.Join(nonNil...)
+	}
+}
@@ -0,0 +1,283 @@
+// parser_response.go - 响应文件展开
+//
+// 该文件实现在解析前将 @path 形式的参数替换为响应文件中参数列表的功能
+
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// responseFilePrefix 响应文件参数前缀
+const responseFilePrefix = "@"
+
+// responseExpander 响应文件展开器
+//
+// 在一次展开过程中记录当前的文件嵌套链和扫描状态
+type responseExpander struct {
+	cmd            types.Command   // 当前命令
+	out            []string        // 展开后的参数列表
+	active         map[string]bool // 正在展开的文件 (绝对路径), 用于检测循环引用
+	scanner        *argScanner     // 标志扫描器, 用于识别取走下一个参数作为值的标志
+	stopped        bool            // 是否已停止展开 (遇到 -- 或子命令名)
+	seenPositional bool            // 是否已遇到位置参数
+	pendingValue   bool            // 下一个参数是否为上一个标志的值
+}
+
+// expandResponseFiles 展开参数列表中的响应文件
+//
+// 参数:
+//   - cmd: 当前命令
+//   - args: 命令行参数列表
+//
+// 返回值:
+//   - []string: 展开后的参数列表
+//   - error: 读取文件失败、引号未闭合或存在循环引用时返回错误
+//
+// 注意事项:
+//   - 以 @ 开头的参数视为响应文件路径, @@ 开头的参数去掉一个 @ 后作为字面参数
+//   - 单独的 @ 视为普通参数
+//   - 遇到 -- 或子命令名后停止展开, 后续参数原样保留 (子命令由自身的解析过程处理)
+//   - 响应文件中的相对路径相对于所在文件的目录解析
+func expandResponseFiles(cmd types.Command, args []string) ([]string, error) {
+	e := &responseExpander{
+		cmd:     cmd,
+		out:     make([]string, 0, len(args)),
+		active:  make(map[string]bool),
+		scanner: newArgScanner(cmd),
+	}
+	if err := e.expand(args, ""); err != nil {
+		return nil, fmt.Errorf("expand response file failed in '%s': %w", cmd.Name(), err)
+	}
+	return e.out, nil
+}
+
+// expand 展开参数列表
+//
+// 参数:
+//   - args: 参数列表
+//   - baseDir: 解析相对路径的目录, 为空表示当前工作目录
+//
+// 返回值:
+//   - error: 展开失败时返回错误
+func (e *responseExpander) expand(args []string, baseDir string) error {
+	for _, arg := range args {
+		switch {
+		case e.stopped:
+			// 停止展开后原样保留, 文件中的相对路径改写为绝对路径, 以便子命令展开时仍能找到
+			if isResponseFileArg(arg) && baseDir != "" && !filepath.IsAbs(arg[len(responseFilePrefix):]) {
+				arg = responseFilePrefix + filepath.Join(baseDir, arg[len(responseFilePrefix):])
+			}
+			e.out = append(e.out, arg)
+
+		case strings.HasPrefix(arg, responseFilePrefix+responseFilePrefix):
+			// 转义的字面参数
+			e.add(arg[len(responseFilePrefix):])
+
+		case isResponseFileArg(arg):
+			path := arg[len(responseFilePrefix):]
+			if baseDir != "" && !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+			if err := e.expandFile(path); err != nil {
+				return err
+			}
+
+		default:
+			e.add(arg)
+		}
+	}
+	return nil
+}
+
+// isResponseFileArg 判断参数是否引用响应文件
+//
+// 参数:
+//   - arg: 命令行参数
+//
+// 返回值:
+//   - bool: 以单个 @ 开头且后跟路径时返回true
+func isResponseFileArg(arg string) bool {
+	return strings.HasPrefix(arg, responseFilePrefix) &&
+		!strings.HasPrefix(arg, responseFilePrefix+responseFilePrefix) &&
+		len(arg) > len(responseFilePrefix)
+}
+
+// add 追加一个展开后的参数, 并更新扫描状态
+//
+// 参数:
+//   - arg: 参数
+//
+// 注意事项:
+//   - 与解析阶段使用同一个扫描器跳过标志值, 如 --name build 中的 build 不会被当作子命令或位置参数
+func (e *responseExpander) add(arg string) {
+	e.out = append(e.out, arg)
+
+	if e.pendingValue {
+		e.pendingValue = false
+		return
+	}
+	if arg == "--" {
+		e.stopped = true
+		return
+	}
+	if isFlagArg(arg) {
+		e.pendingValue = e.takesNext(arg)
+		return
+	}
+	if !e.seenPositional && isSubCmdName(e.cmd, arg) {
+		e.stopped = true
+		return
+	}
+	e.seenPositional = true
+}
+
+// takesNext 判断标志参数是否取走下一个参数作为值
+//
+// 参数:
+//   - arg: 标志参数
+//
+// 返回值:
+//   - bool: 扫描该参数时取走了下一个参数返回true; 未知标志等扫描错误返回false
+func (e *responseExpander) takesNext(arg string) bool {
+	_, rest, err := e.scanner.scan(arg, []string{""})
+	return err == nil && len(rest) == 0
+}
+
+// expandFile 读取并展开一个响应文件
+//
+// 参数:
+//   - path: 响应文件路径
+//
+// 返回值:
+//   - error: 读取失败、内容格式错误或存在循环引用时返回错误
+func (e *responseExpander) expandFile(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolve response file '%s': %w", path, err)
+	}
+	if e.active[absPath] {
+		return fmt.Errorf("response file cycle detected: %s", path)
+	}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return fmt.Errorf("read response file '%s': %w", path, err)
+	}
+
+	tokens, err := splitResponseFile(string(data))
+	if err != nil {
+		return fmt.Errorf("parse response file '%s': %w", path, err)
+	}
+
+	e.active[absPath] = true
+	defer delete(e.active, absPath)
+
+	return e.expand(tokens, filepath.Dir(absPath))
+}
+
+// splitResponseFile 按类 shell 规则拆分响应文件内容
+//
+// 参数:
+//   - content: 文件内容
+//
+// 返回值:
+//   - []string: 参数列表
+//   - error: 引号未闭合时返回错误
+//
+// 拆分规则:
+//   - 空白字符 (空格、制表符、换行) 分隔参数
+//   - 参数开头的 # 表示注释, 直到行尾
+//   - 单引号内的内容原样保留
+//   - 双引号内可使用 \" 和 \\ 转义
+//   - 引号外的反斜杠转义下一个字符, 行尾的反斜杠表示续行
+func splitResponseFile(content string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		inToken bool // 当前是否处于参数中 (用于保留 "" 这样的空参数)
+	)
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, current.String())
+			current.Reset()
+			inToken = false
+		}
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+
+		case r == '#' && !inToken:
+			// 跳过注释直到行尾
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '\'':
+			inToken = true
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+
+		case r == '"':
+			inToken = true
+			closed := false
+			for i++; i < len(runes); i++ {
+				c := runes[i]
+				if c == '"' {
+					closed = true
+					break
+				}
+				if c == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+					i++
+					c = runes[i]
+				}
+				current.WriteRune(c)
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+
+		case r == '\\':
+			if i+1 >= len(runes) {
+				break
+			}
+			i++
+			// 反斜杠加换行表示续行
+			if runes[i] == '\n' {
+				continue
+			}
+			if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+				i++
+				continue
+			}
+			inToken = true
+			current.WriteRune(runes[i])
+
+		default:
+			inToken = true
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens, nil
+}
@@ -0,0 +1,548 @@
+// parser_scan.go - 命令行参数扫描器
+//
+// 该文件实现 GNU/POSIX 风格的参数扫描, 取代标准库 flag.FlagSet
+
+package parser
+
+import (
+	"errors"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
+)
+
+// flagToken 扫描得到的标志记号
+//
+// 一个命令行参数可能产生多个记号, 例如组合短标志 -vxf file
+type flagToken struct {
+	flag     types.Flag // 匹配到的标志
+	name     string     // 用户输入的标志形式, 如 -v 或 --verbose
+	value    string     // 标志值
+	hasValue bool       // 是否显式提供了值
+}
+
+// argScanner 命令行参数扫描器
+//
+// 支持的语法:
+//   - --long, --long=value, --long value
+//   - -s, -s value, -svalue, -s=value
+//   - -abc 组合短标志, 最后一个需要值的标志可携带附加值, 如 -vxf file 或 -vxffile
+//   - 设置了隐式值的标志: --color, --color=never, -c, -cnever (不取走下一个参数)
+//   - --no-long 将支持取反的布尔标志设置为 false
+//   - 开启缩写模式时, --lo 等唯一前缀代替完整的长名称
+//   - -- 终止标志解析, 单独的 - 视为位置参数
+type argScanner struct {
+	cmd        types.Command         // 当前命令
+	longFlags  map[string]types.Flag // 长名称到标志的映射
+	shortFlags map[string]types.Flag // 短名称到标志的映射
+	maxShort   int                   // 最长短名称的长度
+	abbrev     bool                  // 是否允许缩写长标志
+}
+
+// newArgScanner 创建参数扫描器
+//
+// 扫描器识别命令自身的标志以及从祖先命令继承的持久标志
+//
+// 参数:
+//   - cmd: 当前命令
+//
+// 返回值:
+//   - *argScanner: 参数扫描器实例
+func newArgScanner(cmd types.Command) *argScanner {
+	s := &argScanner{
+		cmd:        cmd,
+		longFlags:  make(map[string]types.Flag),
+		shortFlags: make(map[string]types.Flag),
+		abbrev:     utils.AbbrevEnabled(cmd),
+	}
+
+	// 命令自身的标志优先, 继承的持久标志不覆盖同名标志
+	flags := append(cmd.FlagRegistry().List(), cmd.InheritedFlags()...)
+	for _, f := range flags {
+		if f.LongName() != "" {
+			if _, ok := s.longFlags[f.LongName()]; !ok {
+				s.longFlags[f.LongName()] = f
+			}
+		}
+		if f.ShortName() != "" {
+			if _, ok := s.shortFlags[f.ShortName()]; !ok {
+				s.shortFlags[f.ShortName()] = f
+			}
+			if len(f.ShortName()) > s.maxShort {
+				s.maxShort = len(f.ShortName())
+			}
+		}
+	}
+
+	return s
+}
+
+// isFlagArg 判断参数是否为标志形式
+//
+// 参数:
+//   - arg: 命令行参数
+//
+// 返回值:
+//   - bool: 以 - 开头且长度大于1时返回true (单独的 - 视为位置参数)
+func isFlagArg(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
+
+// takesValue 检查标志是否需要值
+//
+// 参数:
+//   - f: 标志
+//
+// 返回值:
+//   - bool: 布尔标志、计数标志和设置了隐式值的标志返回false, 其他返回true
+func takesValue(f types.Flag) bool {
+	if f.Type() == types.FlagTypeBool || f.Type() == types.FlagTypeCount {
+		return false
+	}
+	_, ok := f.ImplicitValue()
+	return !ok
+}
+
+// hasImplicitValue 检查标志是否设置了隐式值
+//
+// 参数:
+//   - f: 标志
+//
+// 返回值:
+//   - bool: 设置了隐式值时返回true
+func hasImplicitValue(f types.Flag) bool {
+	_, ok := f.ImplicitValue()
+	return ok
+}
+
+// noValueDefault 获取标志不带值出现时使用的值
+//
+// 参数:
+//   - f: 标志
+//
+// 返回值:
+//   - string: 布尔标志为 "true", 设置了隐式值的标志为隐式值,
+//     其他 (计数标志) 为空字符串
+func noValueDefault(f types.Flag) string {
+	if f.Type() == types.FlagTypeBool {
+		return "true"
+	}
+	if v, ok := f.ImplicitValue(); ok {
+		return v
+	}
+	return ""
+}
+
+// scan 扫描一个标志参数
+//
+// 参数:
+//   - arg: 以 - 开头的标志参数 (不能是 --)
+//   - rest: 该参数之后的剩余参数, 需要值的标志可能从中取走下一个参数
+//
+// 返回值:
+//   - []flagToken: 该参数产生的标志记号
+//   - []string: 取走值之后的剩余参数
+//   - error: 未知标志返回 UnknownFlagError, 语法错误或缺少值返回普通错误
+func (s *argScanner) scan(arg string, rest []string) ([]flagToken, []string, error) {
+	if strings.HasPrefix(arg, "--") {
+		return s.scanLong(arg, rest)
+	}
+	return s.scanShort(arg, rest)
+}
+
+// scanLong 扫描长标志参数
+//
+// 参数:
+//   - arg: 以 -- 开头的标志参数
+//   - rest: 剩余参数
+//
+// 返回值:
+//   - []flagToken: 标志记号
+//   - []string: 剩余参数
+//   - error: 扫描失败时返回错误
+func (s *argScanner) scanLong(arg string, rest []string) ([]flagToken, []string, error) {
+	body := arg[2:]
+	name, value, hasValue := strings.Cut(body, "=")
+	if name == "" || strings.HasPrefix(name, "-") {
+		return nil, rest, newUnknownFlagError(s.cmd, arg)
+	}
+
+	// 缩写: 唯一前缀展开为完整名称
+	if s.abbrev && s.longFlags[name] == nil && s.lookupNegated(name) == nil {
+		full, err := s.expandAbbrev(name)
+		if err != nil {
+			return nil, rest, err
+		}
+		name = full
+	}
+
+	f, ok := s.longFlags[name]
+	if !ok {
+		// 取反形式: --no-name 将布尔标志设置为 false
+		if f := s.lookupNegated(name); f != nil {
+			if hasValue {
+				return nil, rest, &types.UnexpectedValueError{Command: s.cmd.Name(), Path: s.cmd.Path(), Flag: "--" + name, Value: value}
+			}
+			return []flagToken{{flag: f, name: "--" + name, value: "false", hasValue: true}}, rest, nil
+		}
+		return nil, rest, newUnknownFlagError(s.cmd, "--"+name)
+	}
+
+	tok := flagToken{flag: f, name: "--" + name, value: value, hasValue: hasValue}
+	if !hasValue && takesValue(f) {
+		if len(rest) == 0 {
+			return nil, rest, &types.MissingValueError{Command: s.cmd.Name(), Path: s.cmd.Path(), Flag: tok.name}
+		}
+		tok.value, tok.hasValue, rest = rest[0], true, rest[1:]
+	}
+
+	return []flagToken{tok}, rest, nil
+}
+
+// lookupNegated 查找取反形式对应的标志
+//
+// 参数:
+//   - name: 长标志名称, 不含 -- 前缀
+//
+// 返回值:
+//   - types.Flag: name 为 no-<long> 且对应标志支持取反时返回该标志, 否则为nil
+func (s *argScanner) lookupNegated(name string) types.Flag {
+	long, ok := strings.CutPrefix(name, types.NegatePrefix)
+	if !ok {
+		return nil
+	}
+	if f, ok := s.longFlags[long]; ok && f.IsNegatable() {
+		return f
+	}
+	return nil
+}
+
+// expandAbbrev 将长标志前缀展开为完整名称
+//
+// 参数:
+//   - prefix: 用户输入的长标志前缀, 不含 -- 前缀
+//
+// 返回值:
+//   - string: 唯一匹配的完整名称; 没有匹配时原样返回, 由调用方报告未知标志
+//   - error: 前缀匹配多个标志时返回 AmbiguousFlagError
+//
+// 注意事项:
+//   - 候选名称包括长名称和支持取反的布尔标志的 no- 形式
+func (s *argScanner) expandAbbrev(prefix string) (string, error) {
+	names := make([]string, 0, len(s.longFlags)*2)
+	for long, f := range s.longFlags {
+		names = append(names, long)
+		if f.IsNegatable() {
+			names = append(names, types.NegatePrefix+long)
+		}
+	}
+
+	matches := utils.MatchPrefix(names, prefix)
+	switch len(matches) {
+	case 0:
+		return prefix, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, len(matches))
+	for i, m := range matches {
+		candidates[i] = "--" + m
+	}
+	return "", &types.AmbiguousFlagError{
+		Command:    s.cmd.Name(),
+		Path:       s.cmd.Path(),
+		Input:      "--" + prefix,
+		Candidates: candidates,
+	}
+}
+
+// scanShort 扫描短标志参数
+//
+// 参数:
+//   - arg: 以单个 - 开头的标志参数
+//   - rest: 剩余参数
+//
+// 返回值:
+//   - []flagToken: 标志记号
+//   - []string: 剩余参数
+//   - error: 扫描失败时返回错误
+//
+// 注意事项:
+//   - 整体匹配已注册短名称时 (包括多字符短名称及 -s=value 形式) 直接使用该标志
+//   - 否则按组合短标志处理, 每一步匹配最长的已注册短名称
+//   - 需要值的标志会取走组合中剩余的字符作为值, 没有剩余字符时取走下一个参数
+//   - 设置了隐式值的标志只取走组合中剩余的字符, 不会取走下一个参数
+//   - 无法按组合短标志解析时, 整体匹配长名称的单横杠写法 (如 -verbose、-port=8080) 按长标志处理,
+//     兼容旧版本基于标准库的解析行为
+func (s *argScanner) scanShort(arg string, rest []string) ([]flagToken, []string, error) {
+	body := arg[1:]
+
+	// 整体匹配: -s, -st, -s=value
+	name, value, hasValue := strings.Cut(body, "=")
+	if f, ok := s.shortFlags[name]; ok {
+		tok := flagToken{flag: f, name: "-" + name, value: value, hasValue: hasValue}
+		if !hasValue && takesValue(f) {
+			if len(rest) == 0 {
+				return nil, rest, &types.MissingValueError{Command: s.cmd.Name(), Path: s.cmd.Path(), Flag: tok.name}
+			}
+			tok.value, tok.hasValue, rest = rest[0], true, rest[1:]
+		}
+		return []flagToken{tok}, rest, nil
+	}
+
+	// 组合短标志及附加值: -vxf, -ofile, -vxffile
+	var tokens []flagToken
+	for body != "" {
+		f, short := s.lookupShort(body)
+		if f == nil {
+			if f, ok := s.longFlags[name]; ok && len(name) > 1 {
+				return s.scanSingleDashLong(f, name, value, hasValue, rest)
+			}
+			return nil, rest, newUnknownFlagError(s.cmd, "-"+body[:1])
+		}
+		body = body[len(short):]
+
+		tok := flagToken{flag: f, name: "-" + short}
+		if takesValue(f) {
+			switch {
+			case body != "":
+				tok.value, tok.hasValue, body = body, true, ""
+			case len(rest) > 0:
+				tok.value, tok.hasValue, rest = rest[0], true, rest[1:]
+			default:
+				return nil, rest, &types.MissingValueError{Command: s.cmd.Name(), Path: s.cmd.Path(), Flag: tok.name}
+			}
+		} else if hasImplicitValue(f) && body != "" {
+			// 可选值只能附加在标志之后, 如 -cnever
+			tok.value, tok.hasValue, body = body, true, ""
+		}
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, rest, nil
+}
+
+// scanSingleDashLong 扫描单横杠写法的长标志
+//
+// 参数:
+//   - f: 长名称匹配的标志
+//   - name: 长名称
+//   - value: = 之后的值
+//   - hasValue: 是否使用 = 附带了值
+//   - rest: 剩余参数
+//
+// 返回值:
+//   - []flagToken: 标志记号
+//   - []string: 剩余参数
+//   - error: 缺少值时返回 MissingValueError
+//
+// 注意事项:
+//   - 只接受完整的长名称, 不支持缩写和 no- 取反形式
+func (s *argScanner) scanSingleDashLong(f types.Flag, name, value string, hasValue bool, rest []string) ([]flagToken, []string, error) {
+	tok := flagToken{flag: f, name: "-" + name, value: value, hasValue: hasValue}
+	if !hasValue && takesValue(f) {
+		if len(rest) == 0 {
+			return nil, rest, &types.MissingValueError{Command: s.cmd.Name(), Path: s.cmd.Path(), Flag: tok.name}
+		}
+		tok.value, tok.hasValue, rest = rest[0], true, rest[1:]
+	}
+	return []flagToken{tok}, rest, nil
+}
+
+// lookupShort 查找与字符串前缀匹配的最长短名称
+//
+// 参数:
+//   - body: 组合短标志中尚未处理的部分
+//
+// 返回值:
+//   - types.Flag: 匹配到的标志, 未匹配时为nil
+//   - string: 匹配到的短名称
+func (s *argScanner) lookupShort(body string) (types.Flag, string) {
+	n := s.maxShort
+	if n > len(body) {
+		n = len(body)
+	}
+	for ; n > 0; n-- {
+		if f, ok := s.shortFlags[body[:n]]; ok {
+			return f, body[:n]
+		}
+	}
+	return nil, ""
+}
+
+// parseArgs 解析命令行参数并设置标志值
+//
+// 参数:
+//   - cmd: 当前命令
+//   - args: 命令行参数列表
+//   - interspersed: 是否允许标志与位置参数交替出现
+//
+// 返回值:
+//   - []string: 剩余的位置参数
+//   - error: 解析失败时返回错误
+//
+// 注意事项:
+//   - 默认遇到第一个非标志参数时停止解析
+//   - 交替模式下继续解析位置参数之后的标志, 位置参数按原顺序收集;
+//     但第一个位置参数是子命令名时仍然停止, 后续参数交给子命令处理
+//   - 遇到 -- 时停止解析并丢弃 --
+//   - 无值的布尔标志设置为 true, 无值的计数标志计数加一, 无值的可选值标志使用隐式值
+//   - 开启累积模式的标志重复出现时追加或合并值, 合并后的值由 validateAccumulated 统一验证
+//   - 错误收集模式下, 无效值被报告后继续解析; 未知标志已在预检查中报告, 直接跳过
+func (p *DefaultParser) parseArgs(cmd types.Command, args []string, interspersed bool) ([]string, error) {
+	scanner := newArgScanner(cmd)
+	var positionals []string
+
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			return append(positionals, args[1:]...), nil
+		}
+		if !isFlagArg(arg) {
+			if !interspersed || (len(positionals) == 0 && isSubCmdName(cmd, arg)) {
+				return append(positionals, args...), nil
+			}
+			positionals = append(positionals, arg)
+			args = args[1:]
+			continue
+		}
+
+		tokens, rest, err := scanner.scan(arg, args[1:])
+		if err != nil {
+			if p.collectErrors && isUnknownFlagError(err) {
+				args = args[1:]
+				continue
+			}
+			return append(positionals, args...), p.report(err)
+		}
+		args = rest
+
+		for _, tok := range tokens {
+			value := tok.value
+			if !tok.hasValue {
+				value = noValueDefault(tok.flag)
+			}
+			if err := setFlagValue(tok.flag, value); err != nil {
+				if err := p.report(newInvalidValueError(cmd, tok.flag, value, types.SourceCLI, tok.name, err)); err != nil {
+					return append(positionals, args...), err
+				}
+				continue
+			}
+			tok.flag.SetOrigin(types.ValueOrigin{Source: types.SourceCLI, Raw: value, Location: tok.name})
+		}
+	}
+
+	return positionals, nil
+}
+
+// validateAccumulated 验证命令行中累积过的标志
+//
+// 参数:
+//   - cmd: 当前命令
+//
+// 返回值:
+//   - error: 验证失败时返回错误, 错误收集模式下报告后返回nil
+//
+// 功能说明:
+//   - 累积模式的标志在 Append 时不验证, 以免 --tag a --tag b 这类
+//     只有合并后才满足验证器的命令行在第一次出现时就被拒绝
+//   - 命令行解析完成后对每个累积标志调用一次 Validate, 验证合并后的完整值
+//   - 剩余参数以子命令开头时, 持久标志可能在子命令中继续累积, 交给子命令验证
+func (p *DefaultParser) validateAccumulated(cmd types.Command) error {
+	var flags []types.Flag
+	if len(p.args) > 0 && isSubCmdName(cmd, p.args[0]) {
+		persistent := make(map[types.Flag]bool)
+		for _, f := range cmd.PersistentFlags() {
+			persistent[f] = true
+		}
+		for _, f := range cmd.FlagRegistry().List() {
+			if !persistent[f] {
+				flags = append(flags, f)
+			}
+		}
+	} else {
+		flags = append(cmd.FlagRegistry().List(), cmd.InheritedFlags()...)
+	}
+
+	for _, f := range flags {
+		acc, ok := f.(types.Accumulator)
+		if !ok || !acc.IsAccumulate() {
+			continue
+		}
+		if err := acc.Validate(); err != nil {
+			origin := f.Origin()
+			if err := p.report(newInvalidValueError(cmd, f, f.GetStr(), types.SourceCLI, origin.Location, err)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isUnknownFlagError 检查错误是否为未知标志或标志缩写歧义错误
+//
+// 参数:
+//   - err: 错误
+//
+// 返回值:
+//   - bool: 是 UnknownFlagError 或 AmbiguousFlagError 时返回true
+func isUnknownFlagError(err error) bool {
+	var unknownErr *types.UnknownFlagError
+	var ambiguousErr *types.AmbiguousFlagError
+	return errors.As(err, &unknownErr) || errors.As(err, &ambiguousErr)
+}
+
+// setFlagValue 设置命令行中出现的标志值
+//
+// 参数:
+//   - f: 标志
+//   - value: 标志值
+//
+// 返回值:
+//   - error: 设置失败时返回错误
+//
+// 注意事项:
+//   - 开启累积模式的标志使用 Append, 其他标志使用 Set
+func setFlagValue(f types.Flag, value string) error {
+	if acc, ok := f.(types.Accumulator); ok && acc.IsAccumulate() {
+		return acc.Append(value)
+	}
+	return f.Set(value)
+}
+
+// isSubCmdName 检查参数是否为子命令名称
+//
+// 参数:
+//   - cmd: 当前命令
+//   - arg: 命令行参数
+//
+// 返回值:
+//   - bool: 是子命令名称时返回true; 开启缩写模式时, 子命令的前缀 (包括有歧义的前缀) 也返回true
+func isSubCmdName(cmd types.Command, arg string) bool {
+	sub, candidates := utils.ResolveSubCmd(cmd, arg)
+	return sub != nil || len(candidates) > 0
+}
+
+// resolveSubCmd 根据名称或唯一前缀查找子命令
+//
+// 参数:
+//   - cmd: 当前命令
+//   - arg: 命令行参数
+//
+// 返回值:
+//   - types.Command: 匹配到的子命令, 未匹配时为nil
+//   - error: 前缀匹配多个子命令时返回 AmbiguousSubcommandError
+func resolveSubCmd(cmd types.Command, arg string) (types.Command, error) {
+	sub, candidates := utils.ResolveSubCmd(cmd, arg)
+	if len(candidates) > 0 {
+		return nil, &types.AmbiguousSubcommandError{
+			Command:    cmd.Name(),
+			Path:       cmd.Path(),
+			Input:      arg,
+			Candidates: candidates,
+		}
+	}
+	return sub, nil
+}
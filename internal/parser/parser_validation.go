@@ -91,6 +91,7 @@ func (p *DefaultParser) buildSetFlagsMap(cmd types.Command) map[string]bool {
 // validateMutexGroups 验证命令的互斥组规则
 //
 // 参数:
+//   - cmd: 当前命令
 //   - config: 命令配置
 //
 // 返回值:
@@ -106,12 +107,13 @@ func (p *DefaultParser) buildSetFlagsMap(cmd types.Command) map[string]bool {
 //   - 如果 AllowNone 为 false, 则必须至少有一个标志被设置
 //
 // 错误处理:
-//   - 使用 fmt.Errorf 创建错误
+//   - 返回 *types.MutexGroupError 错误
 //   - 错误信息包含互斥组名称和冲突的标志列表
+//   - 错误收集模式下报告所有违规的互斥组
 //
 // 性能优化:
 //   - 使用缓存的已设置标志映射，避免重复的 GetFlag() 和 IsSet() 调用
-func (p *DefaultParser) validateMutexGroups(config *types.CmdConfig) error {
+func (p *DefaultParser) validateMutexGroups(cmd types.Command, config *types.CmdConfig) error {
 	// 检查互斥组是否为空
 	if len(config.MutexGroups) == 0 {
 		return nil
@@ -148,12 +150,16 @@ func (p *DefaultParser) validateMutexGroups(config *types.CmdConfig) error {
 
 		// 如果互斥组中设置了多个标志, 返回错误
 		if setCount > 1 {
-			return fmt.Errorf("mutually exclusive flags %v in group '%s' cannot be used together", setFlagsList, group.Name)
+			if err := p.report(&types.MutexGroupError{Command: cmd.Name(), Path: cmd.Path(), Group: group.Name, Flags: setFlagsList}); err != nil {
+				return err
+			}
 		}
 
 		// 如果不允许为空, 且互斥组中没有设置任何标志, 返回错误
 		if !group.AllowNone && setCount == 0 {
-			return fmt.Errorf("one of flags %v in mutex group '%s' must be set", group.Flags, group.Name)
+			if err := p.report(&types.MutexGroupError{Command: cmd.Name(), Path: cmd.Path(), Group: group.Name, Flags: group.Flags, NoneSet: true}); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -163,6 +169,7 @@ func (p *DefaultParser) validateMutexGroups(config *types.CmdConfig) error {
 // validateRequiredGroups 验证命令的必需组规则
 //
 // 参数:
+//   - cmd: 当前命令
 //   - config: 命令配置
 //
 // 返回值:
@@ -178,13 +185,14 @@ func (p *DefaultParser) validateMutexGroups(config *types.CmdConfig) error {
 //   - 条件性必需组：如果任何一个标志被设置，则所有标志都必须被设置
 //
 // 错误处理:
-//   - 使用 fmt.Errorf 创建错误
+//   - 返回 *types.RequiredGroupError 错误
 //   - 错误信息包含必需组名称和未设置的标志列表
 //   - 条件性必需组的错误信息会明确指出是因为使用了某个标志而要求其他标志
+//   - 错误收集模式下报告所有违规的必需组
 //
 // 性能优化:
 //   - 使用缓存的已设置标志映射，避免重复的 GetFlag() 和 IsSet() 调用
-func (p *DefaultParser) validateRequiredGroups(config *types.CmdConfig) error {
+func (p *DefaultParser) validateRequiredGroups(cmd types.Command, config *types.CmdConfig) error {
 	if len(config.RequiredGroups) == 0 {
 		return nil
 	}
@@ -231,10 +239,15 @@ func (p *DefaultParser) validateRequiredGroups(config *types.CmdConfig) error {
 
 		// 如果组中有未设置的标志, 返回错误
 		if len(unsetFlags) > 0 {
-			if group.Conditional {
-				return fmt.Errorf("flags %v in group '%s' must all be set", unsetFlags, group.Name)
+			if err := p.report(&types.RequiredGroupError{
+				Command:     cmd.Name(),
+				Path:        cmd.Path(),
+				Group:       group.Name,
+				Missing:     unsetFlags,
+				Conditional: group.Conditional,
+			}); err != nil {
+				return err
 			}
-			return fmt.Errorf("required flags %v in group '%s' must be set", unsetFlags, group.Name)
 		}
 	}
 
@@ -244,6 +257,7 @@ func (p *DefaultParser) validateRequiredGroups(config *types.CmdConfig) error {
 // validateFlagDependencies 验证标志依赖关系
 //
 // 参数:
+//   - cmd: 当前命令
 //   - config: 命令配置
 //
 // 返回值:
@@ -254,7 +268,8 @@ func (p *DefaultParser) validateRequiredGroups(config *types.CmdConfig) error {
 //   - 只有当触发标志被设置时才进行验证
 //   - 根据依赖类型执行相应的验证逻辑
 //   - 提供清晰的错误信息
-func (p *DefaultParser) validateFlagDependencies(config *types.CmdConfig) error {
+//   - 错误收集模式下报告所有违规的依赖关系
+func (p *DefaultParser) validateFlagDependencies(cmd types.Command, config *types.CmdConfig) error {
 	if len(config.FlagDependencies) == 0 {
 		return nil
 	}
@@ -294,8 +309,16 @@ func (p *DefaultParser) validateFlagDependencies(config *types.CmdConfig) error
 			// 如果有冲突标志, 返回错误
 			if len(conflictFlags) > 0 {
 				triggerDisplay := p.flagDisplayNames[dep.Trigger]
-				return fmt.Errorf("flag %s cannot be used with %v (dependency: %s)",
-					triggerDisplay, conflictFlags, dep.Name)
+				if err := p.report(&types.DependencyError{
+					Command:    cmd.Name(),
+					Path:       cmd.Path(),
+					Dependency: dep.Name,
+					Trigger:    triggerDisplay,
+					Type:       dep.Type,
+					Flags:      conflictFlags,
+				}); err != nil {
+					return err
+				}
 			}
 
 		case types.DepRequired:
@@ -323,8 +346,16 @@ func (p *DefaultParser) validateFlagDependencies(config *types.CmdConfig) error
 			// 如果有缺失标志, 返回错误
 			if len(missingFlags) > 0 {
 				triggerDisplay := p.flagDisplayNames[dep.Trigger]
-				return fmt.Errorf("flag %s requires flags %v to be set (dependency: %s)",
-					triggerDisplay, missingFlags, dep.Name)
+				if err := p.report(&types.DependencyError{
+					Command:    cmd.Name(),
+					Path:       cmd.Path(),
+					Dependency: dep.Name,
+					Trigger:    triggerDisplay,
+					Type:       dep.Type,
+					Flags:      missingFlags,
+				}); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -60,7 +60,7 @@ func (f *SuggestionFinder) FindForSubcommand(input string, cmd types.Command) []
 
 // FindForFlag 查找标志建议
 //
-// 根据输入字符串，在命令的所有标志中查找相似的标志名
+// 根据输入字符串，在命令的所有标志（包括继承的持久标志和布尔标志的取反形式）中查找相似的标志名
 //
 // 参数:
 //   - input: 用户输入的错误标志
@@ -69,7 +69,7 @@ func (f *SuggestionFinder) FindForSubcommand(input string, cmd types.Command) []
 // 返回值:
 //   - []string: 相似标志列表
 func (f *SuggestionFinder) FindForFlag(input string, cmd types.Command) []string {
-	flags := cmd.FlagRegistry().List()
+	flags := append(cmd.FlagRegistry().List(), cmd.InheritedFlags()...)
 	if len(flags) == 0 {
 		return nil
 	}
@@ -79,6 +79,9 @@ func (f *SuggestionFinder) FindForFlag(input string, cmd types.Command) []string
 		if fl.LongName() != "" {
 			names = append(names, "--"+fl.LongName())
 		}
+		if fl.IsNegatable() {
+			names = append(names, "--"+types.NegatePrefix+fl.LongName())
+		}
 		if fl.ShortName() != "" {
 			names = append(names, "-"+fl.ShortName())
 		}
@@ -131,6 +134,7 @@ func newUnknownSubcommandError(cmd types.Command, input string) error {
 
 	return &types.UnknownSubcommandError{
 		Command:     cmd.Name(),
+		Path:        cmd.Path(),
 		Input:       input,
 		Suggestions: suggestions,
 	}
@@ -150,6 +154,7 @@ func newUnknownFlagError(cmd types.Command, input string) error {
 
 	return &types.UnknownFlagError{
 		Command:     cmd.Name(),
+		Path:        cmd.Path(),
 		Input:       input,
 		Suggestions: suggestions,
 	}
@@ -157,37 +162,33 @@ func newUnknownFlagError(cmd types.Command, input string) error {
 
 // checkUnknownFlags 预扫描参数，检查未知标志
 //
-// 在调用标准库 flag.FlagSet.Parse() 之前，先扫描参数列表，
+// 在设置任何标志值之前，先扫描参数列表，
 // 提前发现未知标志并返回带建议的错误。
 //
 // 判断逻辑:
-//   - 以 - 或 -- 开头的参数一定是标志
+//   - 以 - 或 -- 开头的参数一定是标志（单独的 - 是位置参数）
+//   - 使用与解析相同的扫描规则识别组合短标志和附加值，并跳过标志值
 //   - 如果不在已注册标志列表中，就是错误的标志
 //   - 遇到 -- 停止扫描，后面的都视为位置参数
 //   - 遇到子命令名时停止扫描（后续标志由子命令处理）
+//   - 非交替模式下遇到第一个位置参数停止扫描（与解析范围保持一致）
+//   - 错误收集模式下报告所有未知标志, 跳过未知标志后继续扫描
 //
 // 参数:
 //   - cmd: 当前命令
 //   - args: 命令行参数列表
+//   - interspersed: 是否允许标志与位置参数交替出现
+//   - report: 错误报告函数, 返回nil表示错误已被收集, 继续扫描
 //
 // 返回值:
-//   - error: 如果发现未知标志返回错误，否则返回 nil
-func checkUnknownFlags(cmd types.Command, args []string) error {
-	// 获取所有已注册的标志名（长短名称都包括）
-	registeredFlags := make(map[string]bool)
-	for _, f := range cmd.FlagRegistry().List() {
-		if f.LongName() != "" {
-			registeredFlags["--"+f.LongName()] = true
-			registeredFlags["-"+f.LongName()] = true // 支持单横杠长名称
-		}
-		if f.ShortName() != "" {
-			registeredFlags["-"+f.ShortName()] = true
-		}
-	}
+//   - error: 如果发现未知标志且未被收集返回错误，否则返回 nil
+func checkUnknownFlags(cmd types.Command, args []string, interspersed bool, report func(error) error) error {
+	scanner := newArgScanner(cmd)
+	seenPositional := false
 
 	// 扫描参数
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
+	for len(args) > 0 {
+		arg := args[0]
 
 		// 遇到 -- 停止扫描，后面的都视为位置参数
 		if arg == "--" {
@@ -195,24 +196,35 @@ func checkUnknownFlags(cmd types.Command, args []string) error {
 		}
 
 		// 不是标志格式，检查是否为子命令
-		if !strings.HasPrefix(arg, "-") {
+		if !isFlagArg(arg) {
 			// 如果是子命令名，停止扫描（后续标志由子命令处理）
-			if _, isSubCmd := cmd.CmdRegistry().Get(arg); isSubCmd {
+			if !seenPositional && isSubCmdName(cmd, arg) {
+				break
+			}
+			// 非交替模式下，位置参数之后的内容不会被解析
+			if !interspersed {
 				break
 			}
+			seenPositional = true
+			args = args[1:]
 			continue
 		}
 
-		// 处理 --flag=value 格式
-		flagName := arg
-		if idx := strings.Index(arg, "="); idx != -1 {
-			flagName = arg[:idx]
-		}
-
-		// 不是已注册的标志 → 纠错
-		if !registeredFlags[flagName] {
-			return newUnknownFlagError(cmd, flagName)
+		// 扫描标志（跳过标志值）
+		_, rest, err := scanner.scan(arg, args[1:])
+		if err != nil {
+			// 不是已注册的标志 → 纠错; 缩写有歧义 → 列出候选项
+			if isUnknownFlagError(err) {
+				if err := report(err); err != nil {
+					return err
+				}
+				args = args[1:]
+				continue
+			}
+			// 其他错误（如缺少值）由解析阶段报告
+			break
 		}
+		args = rest
 	}
 
 	return nil
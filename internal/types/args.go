@@ -0,0 +1,40 @@
+package types
+
+// ArgSpec 位置参数声明
+//
+// ArgSpec 描述命令接受的一个命名位置参数。解析时按声明顺序
+// 将位置参数绑定到对应的值容器, 并检查参数个数和类型。
+//
+// 字段说明:
+//   - Name: 参数名称, 用于用法行、帮助信息和错误提示
+//   - Desc: 参数描述, 用于帮助信息
+//   - Required: 是否必需, 必需参数不能出现在可选参数之后
+//   - Variadic: 是否为可变参数, 接收剩余的所有位置参数, 只能是最后一个
+//   - Value: 值容器, 复用标志类型的解析器和验证器;
+//     可变参数的值容器必须实现 ItemSetter 接口 (切片类型)
+//
+// 使用场景:
+//   - cp <src> <dst>: 两个必需参数
+//   - run <script> [args...]: 必需参数加可选的可变参数
+type ArgSpec struct {
+	Name     string // 参数名称
+	Desc     string // 参数描述
+	Required bool   // 是否必需
+	Variadic bool   // 是否为可变参数
+	Value    Flag   // 值容器
+}
+
+// ItemSetter 逐项设置值的接口
+//
+// ItemSetter 由切片类型的标志实现, 用于可变位置参数:
+// 每个位置参数作为一个元素解析, 不按逗号分割。
+type ItemSetter interface {
+	// SetItems 逐项设置值
+	//
+	// 参数:
+	//   - items: 值列表, 每一项对应一个元素
+	//
+	// 返回值:
+	//   - error: 解析或验证失败时返回错误
+	SetItems(items []string) error
+}
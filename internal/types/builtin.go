@@ -76,8 +76,8 @@ type BuiltinFlagHandler interface {
 	//
 	// 功能说明:
 	//   - 执行内置标志的特定操作
-	//   - 通常在执行后会调用 os.Exit 退出程序
-	//   - 例如: 帮助标志会打印帮助信息并退出
+	//   - 处理成功后返回哨兵错误 (如 ErrHelp), 表示请求已处理, 不调用 os.Exit
+	//   - 例如: 帮助标志会打印帮助信息并返回 ErrHelp
 	Handle(cmd Command) error
 
 	// Type 返回标志类型
@@ -123,6 +123,12 @@ const (
 	// BashShell bash shell
 	BashShell = "bash"
 
+	// ZshShell zsh shell
+	ZshShell = "zsh"
+
+	// FishShell fish shell
+	FishShell = "fish"
+
 	// PwshShell pwsh shell
 	PwshShell = "pwsh"
 
@@ -133,6 +139,8 @@ const (
 // Shell切片, 用于存储支持的Shell类型
 var SupportedShells = []string{
 	BashShell,
+	ZshShell,
+	FishShell,
 	PwshShell,
 	PowershellShell,
 }
@@ -188,6 +196,24 @@ const (
 	// 用法: __complete all <cur> <prev> [cmd_args...]
 	// 输出: 多行格式，包含 CONTEXT, CUR, PREV, CANDIDATES, ENUM, MATCHES, IS_FLAG
 	InstructionAll = "all"
+
+	// InstructionJSON JSON 格式的统一补全指令
+	// 用法: __complete json <cur> <prev> [cmd_args...]
+	// 输出: 一个 JSON 对象 (CompleteResponse), 包含版本号、上下文、带描述和类型的候选项及补全指令
+	InstructionJSON = "json"
+)
+
+// __complete 输出格式相关常量
+const (
+	// CompleteFormatEnv 选择 all 指令输出格式的环境变量
+	// 值为 CompleteFormatJSON 时 all 指令输出与 json 指令相同的 JSON 格式
+	CompleteFormatEnv = "QFLAG_COMPLETE_FORMAT"
+
+	// CompleteFormatJSON JSON 输出格式
+	CompleteFormatJSON = "json"
+
+	// CompleteJSONVersion JSON 输出格式的版本号, 格式发生不兼容的变化时递增
+	CompleteJSONVersion = 1
 )
 
 // CompleteCmdName 补全命令名称
@@ -221,6 +247,21 @@ const (
 
 	// BashProfileFileNameLinux Linux Bash 配置文件名
 	BashProfileFileNameLinux = ".bashrc"
+
+	// ZshCompletionsDirName Zsh 补全脚本目录名 (位于补全脚本存放目录下, 加入 fpath)
+	ZshCompletionsDirName = "zsh"
+
+	// ZshProfileFileName Zsh 配置文件名 (位于 $ZDOTDIR 或家目录下)
+	ZshProfileFileName = ".zshrc"
+
+	// FishConfigDirName Fish 配置目录的父目录名 (未设置 $XDG_CONFIG_HOME 时位于家目录下)
+	FishConfigDirName = ".config"
+
+	// FishCompletionsDirName Fish 自动加载补全脚本的目录 (位于配置目录下)
+	FishCompletionsDirName = "fish/completions"
+
+	// FishCompletionScriptExt Fish 补全脚本扩展名
+	FishCompletionScriptExt = ".fish"
 )
 
 // 补全加载命令模板
@@ -232,6 +273,11 @@ const (
 	// BashLoadCommandTemplate Bash 加载命令模板
 	// 参数: 脚本路径（2次）
 	BashLoadCommandTemplate = "[ -f '%s' ] && source '%s'"
+
+	// ZshLoadCommandTemplate Zsh 加载命令模板
+	// 将补全目录加入 fpath, 未初始化补全系统时执行 compinit, 再注册补全函数
+	// 参数: 补全目录（1次）、补全函数名（2次）、程序名（1次）
+	ZshLoadCommandTemplate = "fpath=('%s' $fpath); (( $+functions[compdef] )) || { autoload -Uz compinit && compinit }; autoload -Uz %s && compdef %s %s"
 )
 
 // 补全安装成功信息 - 中文
@@ -244,6 +290,9 @@ const (
 
 	// InstallSuccessHintCN 重启提示（中文）
 	InstallSuccessHintCN = "\n请重启终端或运行以下命令启用补全:"
+
+	// InstallSuccessFishHintCN Fish 自动加载提示（中文）
+	InstallSuccessFishHintCN = "\nfish 会在新会话中自动加载补全, 如需在当前会话启用请运行:"
 )
 
 // 补全安装成功信息 - 英文
@@ -256,6 +305,9 @@ const (
 
 	// InstallSuccessHintEN 重启提示（英文）
 	InstallSuccessHintEN = "\nPlease restart your terminal or run the following command to enable completions:"
+
+	// InstallSuccessFishHintEN Fish 自动加载提示（英文）
+	InstallSuccessFishHintEN = "\nfish loads the completion automatically in new sessions, to enable it in the current session run:"
 )
 
 // 补全执行命令（Shell 命令本身不需要翻译）
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"context"
+	"io"
+)
+
 // Command 接口定义了命令的核心行为
 type Command interface {
 	// 基本属性
@@ -15,6 +20,8 @@ type Command interface {
 	GetFlag(name string) (Flag, bool) // 根据名称获取标志
 	Flags() []Flag                    // 获取所有标志
 	FlagRegistry() FlagRegistry       // 获取标志注册器
+	PersistentFlags() []Flag          // 获取当前命令声明的持久标志
+	InheritedFlags() []Flag           // 获取从祖先命令继承的持久标志
 
 	// 子命令管理
 	AddSubCmds(cmds ...Command) error      // 添加多个子命令
@@ -26,12 +33,14 @@ type Command interface {
 
 	// 命令层次
 	IsRootCmd() bool // 是否为根命令
+	Parent() Command // 获取父命令, 根命令返回nil
 	Path() string    // 命令的路径, 用于显示和帮助
 
 	// 参数解析
 	Parse(args []string) error         // 解析命令行参数
 	ParseAndRoute(args []string) error // 解析并路由到子命令
 	ParseOnly(args []string) error     // 仅解析参数, 不路由
+	Context() context.Context          // 获取执行上下文, 未设置时继承父命令或使用 context.Background()
 	IsParsed() bool                    // 是否已解析参数
 	SetParsed(parsed bool)             // 设置解析状态
 
@@ -40,16 +49,24 @@ type Command interface {
 	Arg(index int) string  // 获取指定索引的参数
 	NArg() int             // 获取参数数量
 	SetArgs(args []string) // 设置参数
+	ArgSpecs() []ArgSpec   // 获取位置参数声明
 
 	// 执行
-	Run() error                    // 执行命令
-	SetRun(fn func(Command) error) // 设置执行函数
-	HasRunFunc() bool              // 是否有执行函数
+	Run() error                          // 执行命令
+	SetRun(fn func(Command) error)       // 设置执行函数
+	HasRunFunc() bool                    // 是否有执行函数
+	Hook(t HookType) func(Command) error // 获取指定类型的生命周期钩子, 未设置时返回nil
+	Middlewares() []Middleware           // 获取命令自身注册的运行中间件, 不包含祖先命令的中间件
 
 	// 帮助信息
 	Help() string // 获取命令帮助信息
 	PrintHelp()   // 打印命令帮助信息
 
+	// 输入输出
+	Out() io.Writer    // 获取标准输出, 未设置时继承父命令或使用 os.Stdout
+	ErrOut() io.Writer // 获取标准错误输出, 未设置时继承父命令或使用 os.Stderr
+	In() io.Reader     // 获取标准输入, 未设置时继承父命令或使用 os.Stdin
+
 	// 配置
 	SetParser(p Parser)                     // 设置解析器
 	SetDesc(desc string)                    // 设置命令描述
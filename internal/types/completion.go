@@ -0,0 +1,180 @@
+package types
+
+// CompletionDirective 补全指令
+//
+// CompletionDirective 告诉补全脚本在补全函数没有返回候选项时如何处理,
+// 多个指令可以按位组合。
+type CompletionDirective int
+
+const (
+	// CompletionDefault 默认行为, 没有候选项时回退到文件补全
+	CompletionDefault CompletionDirective = 0
+
+	// CompletionNoFile 没有候选项时不补全文件
+	CompletionNoFile CompletionDirective = 1 << 0
+
+	// CompletionFilterExt 没有候选项时只补全目录和指定扩展名的文件, 扩展名由 CompletionResult.Extensions 给出
+	CompletionFilterExt CompletionDirective = 1 << 1
+
+	// CompletionDirsOnly 没有候选项时只补全目录
+	CompletionDirsOnly CompletionDirective = 1 << 2
+
+	// CompletionHostnames 没有候选项时补全主机名 (由 Shell 从 hosts 文件等来源获取)
+	CompletionHostnames CompletionDirective = 1 << 3
+)
+
+// ValueKind 值类型
+//
+// ValueKind 声明标志或位置参数的值是什么, 静态和动态补全脚本据此决定如何补全值。
+type ValueKind int
+
+const (
+	// ValueKindAuto 未声明, 非枚举的值回退到 Shell 的默认补全 (文件)
+	ValueKindAuto ValueKind = iota
+
+	// ValueKindFile 文件路径, 可以限定扩展名
+	ValueKindFile
+
+	// ValueKindDir 目录路径
+	ValueKindDir
+
+	// ValueKindHostname 主机名
+	ValueKindHostname
+
+	// ValueKindNone 任意文本, 不提供补全
+	ValueKindNone
+)
+
+// String 返回值类型的名称
+//
+// 返回值:
+//   - string: 值类型名称, 用于补全脚本, ValueKindAuto 返回空字符串
+func (k ValueKind) String() string {
+	switch k {
+	case ValueKindFile:
+		return "file"
+	case ValueKindDir:
+		return "dir"
+	case ValueKindHostname:
+		return "hostname"
+	case ValueKindNone:
+		return "none"
+	default:
+		return ""
+	}
+}
+
+// ValueHint 值类型提示
+//
+// 字段说明:
+//   - Kind: 值类型
+//   - Extensions: ValueKindFile 允许的扩展名, 如 ".yaml", 为空表示不限
+type ValueHint struct {
+	Kind       ValueKind // 值类型
+	Extensions []string  // 允许的扩展名
+}
+
+// Directive 将值类型提示转换为补全指令
+//
+// 返回值:
+//   - CompletionDirective: 对应的补全指令, ValueKindAuto 和 ValueKindFile 为 CompletionDefault
+//     (限定了扩展名时为 CompletionFilterExt)
+func (h ValueHint) Directive() CompletionDirective {
+	switch h.Kind {
+	case ValueKindDir:
+		return CompletionDirsOnly
+	case ValueKindHostname:
+		return CompletionHostnames
+	case ValueKindNone:
+		return CompletionNoFile
+	case ValueKindFile:
+		if len(h.Extensions) > 0 {
+			return CompletionFilterExt
+		}
+	}
+	return CompletionDefault
+}
+
+// CompletionCandidate 补全候选项
+//
+// 字段说明:
+//   - Value: 候选值, 不能包含空白字符
+//   - Desc: 可选的描述, 支持显示描述的 Shell (如 PowerShell) 会一并显示
+type CompletionCandidate struct {
+	Value string // 候选值
+	Desc  string // 候选值描述
+}
+
+// CompletionResult 补全函数的返回结果
+//
+// 字段说明:
+//   - Candidates: 候选项列表, 框架会按当前输入的前缀过滤并排序
+//   - Directive: 没有候选项时的补全指令
+//   - Extensions: CompletionFilterExt 使用的扩展名, 如 ".yaml"
+type CompletionResult struct {
+	Candidates []CompletionCandidate // 候选项列表
+	Directive  CompletionDirective   // 补全指令
+	Extensions []string              // 过滤文件使用的扩展名
+}
+
+// CompletionFunc 动态补全函数
+//
+// 为标志或位置参数的值容器设置补全函数后, 动态补全 (__complete 指令) 补全其值时
+// 调用该函数获取运行时计算的候选项, 如已有的命名空间、git 分支等。
+//
+// 参数:
+//   - cmd: 补全位置所在的命令, 光标之前已输入的标志和位置参数已尽量解析到命令中,
+//     解析失败的值会被忽略, 可通过 Flags()、Args() 等访问
+//   - prefix: 当前正在输入的内容
+//
+// 返回值:
+//   - CompletionResult: 候选项和补全指令
+type CompletionFunc func(cmd Command, prefix string) CompletionResult
+
+// 补全候选项类型, 用于 JSON 格式的补全输出
+const (
+	// CandidateKindCommand 子命令
+	CandidateKindCommand = "command"
+
+	// CandidateKindFlag 标志
+	CandidateKindFlag = "flag"
+
+	// CandidateKindValue 标志值或位置参数值 (枚举值、补全函数返回的候选值)
+	CandidateKindValue = "value"
+)
+
+// CompleteItem JSON 格式补全输出中的候选项
+//
+// 字段说明:
+//   - Value: 候选值, 可以包含空格
+//   - Desc: 描述, 来自标志、子命令的描述或补全函数, 没有时省略
+//   - Kind: 候选项类型, 取值为 CandidateKindCommand、CandidateKindFlag 或 CandidateKindValue
+type CompleteItem struct {
+	Value string `json:"value"`          // 候选值
+	Desc  string `json:"desc,omitempty"` // 描述
+	Kind  string `json:"kind"`           // 候选项类型
+}
+
+// CompleteResponse JSON 格式的补全输出 (__complete json 指令)
+//
+// 字段说明:
+//   - Version: 格式版本号, 当前为 CompleteJSONVersion
+//   - Context: 解析出的命令上下文路径, 如 "/server/start/"
+//   - Cur: 当前输入
+//   - Prev: 前一个词
+//   - Flag: 正在补全其值的标志, 不是在补全标志值时为空
+//   - ValueKind: 正在补全的值声明的值类型 (file、dir、hostname、none), 未声明时为空
+//   - Directive: 没有候选项时的补全指令
+//   - Extensions: CompletionFilterExt 使用的扩展名
+//   - Candidates: 按当前输入过滤和排序后的候选项
+type CompleteResponse struct {
+	Version    int                 `json:"version"`              // 格式版本号
+	Context    string              `json:"context"`              // 上下文路径
+	Cur        string              `json:"cur"`                  // 当前输入
+	Prev       string              `json:"prev"`                 // 前一个词
+	Flag       string              `json:"flag,omitempty"`       // 正在补全其值的标志
+	ValueKind  string              `json:"valueKind,omitempty"`  // 值类型
+	Directive  CompletionDirective `json:"directive"`            // 补全指令
+	Extensions []string            `json:"extensions,omitempty"` // 扩展名
+	Candidates []CompleteItem      `json:"candidates"`           // 候选项
+}
@@ -1,5 +1,7 @@
 package types
 
+import "io"
+
 // DepType 依赖关系类型
 type DepType int
 
@@ -90,18 +92,28 @@ type RequiredGroup struct {
 
 // CmdConfig 命令配置类型
 type CmdConfig struct {
-	Version           string            // 版本号
-	UseChinese        bool              // 是否使用中文
-	EnvPrefix         string            // 环境变量前缀
-	UsageSyntax       string            // 命令使用语法
-	Example           map[string]string // 示例使用, key为描述, value为示例命令
-	Notes             []string          // 注意事项
-	LogoText          string            // 命令logo文本
-	MutexGroups       []MutexGroup      // 互斥组列表
-	RequiredGroups    []RequiredGroup   // 必需组列表
-	FlagDependencies  []FlagDependency  // 标志依赖关系列表
-	Completion        bool              // 是否启用自动补全标志
-	DynamicCompletion bool              // 是否启用动态补全
+	Version            string            // 版本号
+	UseChinese         bool              // 是否使用中文
+	EnvPrefix          string            // 环境变量前缀
+	UsageSyntax        string            // 命令使用语法
+	Example            map[string]string // 示例使用, key为描述, value为示例命令
+	Notes              []string          // 注意事项
+	LogoText           string            // 命令logo文本
+	MutexGroups        []MutexGroup      // 互斥组列表
+	RequiredGroups     []RequiredGroup   // 必需组列表
+	FlagDependencies   []FlagDependency  // 标志依赖关系列表
+	Completion         bool              // 是否启用自动补全标志
+	DynamicCompletion  bool              // 是否启用动态补全
+	Interspersed       bool              // 是否允许标志与位置参数交替出现
+	AllowAbbrev        bool              // 是否允许使用唯一前缀缩写长标志和子命令
+	ResponseFiles      bool              // 是否展开 @file 形式的响应文件
+	CollectErrors      bool              // 是否收集一次解析中的所有错误后汇总返回
+	ConfigFile         string            // 配置文件路径
+	ConfigDecoder      ConfigDecoder     // 配置文件解码器, 为nil时按扩展名选择
+	Out                io.Writer         // 标准输出, 为nil时继承父命令或使用 os.Stdout
+	Err                io.Writer         // 标准错误输出, 为nil时继承父命令或使用 os.Stderr
+	In                 io.Reader         // 标准输入, 为nil时继承父命令或使用 os.Stdin
+	ValidationExitCode int               // 值验证错误的退出码, 为0时继承父命令或使用 ExitCodeUsage
 }
 
 // NewCmdConfig 创建新的命令配置
@@ -110,18 +122,28 @@ type CmdConfig struct {
 //   - *CmdConfig: 新创建的 CmdConfig 实例, 初始化为零值
 func NewCmdConfig() *CmdConfig {
 	return &CmdConfig{
-		Version:           "",
-		UseChinese:        false,
-		EnvPrefix:         "",
-		UsageSyntax:       "",
-		Example:           map[string]string{},
-		Notes:             []string{},
-		LogoText:          "",
-		MutexGroups:       []MutexGroup{},
-		RequiredGroups:    []RequiredGroup{},
-		FlagDependencies:  []FlagDependency{},
-		Completion:        false,
-		DynamicCompletion: false,
+		Version:            "",
+		UseChinese:         false,
+		EnvPrefix:          "",
+		UsageSyntax:        "",
+		Example:            map[string]string{},
+		Notes:              []string{},
+		LogoText:           "",
+		MutexGroups:        []MutexGroup{},
+		RequiredGroups:     []RequiredGroup{},
+		FlagDependencies:   []FlagDependency{},
+		Completion:         false,
+		DynamicCompletion:  false,
+		Interspersed:       false,
+		AllowAbbrev:        false,
+		ResponseFiles:      false,
+		CollectErrors:      false,
+		ConfigFile:         "",
+		ConfigDecoder:      nil,
+		Out:                nil,
+		Err:                nil,
+		In:                 nil,
+		ValidationExitCode: 0,
 	}
 }
 
@@ -141,13 +163,23 @@ func (c *CmdConfig) Clone() *CmdConfig {
 	}
 
 	clone := &CmdConfig{
-		Version:           c.Version,
-		UseChinese:        c.UseChinese,
-		EnvPrefix:         c.EnvPrefix,
-		UsageSyntax:       c.UsageSyntax,
-		LogoText:          c.LogoText,
-		Completion:        c.Completion,
-		DynamicCompletion: c.DynamicCompletion,
+		Version:            c.Version,
+		UseChinese:         c.UseChinese,
+		EnvPrefix:          c.EnvPrefix,
+		UsageSyntax:        c.UsageSyntax,
+		LogoText:           c.LogoText,
+		Completion:         c.Completion,
+		DynamicCompletion:  c.DynamicCompletion,
+		Interspersed:       c.Interspersed,
+		AllowAbbrev:        c.AllowAbbrev,
+		ResponseFiles:      c.ResponseFiles,
+		CollectErrors:      c.CollectErrors,
+		ConfigFile:         c.ConfigFile,
+		ConfigDecoder:      c.ConfigDecoder,
+		Out:                c.Out,
+		Err:                c.Err,
+		In:                 c.In,
+		ValidationExitCode: c.ValidationExitCode,
 	}
 
 	// 深拷贝 Example 映射
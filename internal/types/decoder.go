@@ -0,0 +1,24 @@
+package types
+
+// ConfigDecoder 配置文件解码器接口
+//
+// ConfigDecoder 将配置文件内容解码为嵌套映射, 用于从配置文件加载标志值。
+// 库内置 JSON 和类 TOML/INI 格式的解码器, 其他格式 (如 YAML) 可以通过实现
+// 此接口接入, 而无需 qflag 依赖对应的第三方库。
+//
+// 解码结果约定:
+//   - 顶层键对应根命令 (或设置配置文件的命令) 的标志长名称
+//   - 值为 map[string]any 的键表示子命令分区, 按 Cmd.Path() 逐级嵌套
+//   - 标量值可以是 string、bool、数字等任意可格式化的类型
+//   - 切片值使用 []any, 映射标志的值使用 map[string]any
+type ConfigDecoder interface {
+	// Decode 解码配置文件内容
+	//
+	// 参数:
+	//   - data: 配置文件内容
+	//
+	// 返回值:
+	//   - map[string]any: 解码后的嵌套映射
+	//   - error: 内容格式错误时返回错误
+	Decode(data []byte) (map[string]any, error)
+}
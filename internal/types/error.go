@@ -1,12 +1,28 @@
 // error.go - 错误类型定义
 //
-// 该文件包含 qflag 使用的自定义错误类型，支持智能纠错提示功能
+// 该文件包含 qflag 使用的自定义错误类型，支持智能纠错提示功能。
+// 每种解析失败都有对应的错误类型, 调用方可以使用 errors.As 取出命令路径、
+// 标志名、错误值、组名和可选值等信息, 自行渲染错误信息或选择退出码。
 
 package types
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"syscall"
+)
+
+var (
+	// ErrHelp 用户请求了帮助信息 (如 --help)
+	ErrHelp = errors.New("help requested")
+
+	// ErrVersion 用户请求了版本信息 (如 --version)
+	ErrVersion = errors.New("version requested")
+
+	// ErrCompletion 用户请求了生成或安装补全脚本 (如 --completion)
+	ErrCompletion = errors.New("completion requested")
 )
 
 // UnknownSubcommandError 未知子命令错误
@@ -14,6 +30,7 @@ import (
 // 当用户输入的子命令不存在时返回此错误，包含相似子命令建议
 type UnknownSubcommandError struct {
 	Command     string   // 当前命令名
+	Path        string   // 当前命令路径
 	Input       string   // 用户输入的错误子命令
 	Suggestions []string // 相似子命令建议列表
 }
@@ -31,12 +48,7 @@ func (e *UnknownSubcommandError) Error() string {
 	_, _ = fmt.Fprintf(&sb, "%s: '%s' is not a valid command. See '%s --help'.\n",
 		e.Command, e.Input, e.Command)
 
-	if len(e.Suggestions) > 0 {
-		sb.WriteString("\nThe most similar commands are\n")
-		for _, sug := range e.Suggestions {
-			_, _ = fmt.Fprintf(&sb, "\t%s\n", sug)
-		}
-	}
+	writeCandidates(&sb, "The most similar commands are", e.Suggestions)
 
 	return sb.String()
 }
@@ -46,6 +58,7 @@ func (e *UnknownSubcommandError) Error() string {
 // 当用户输入的标志不存在时返回此错误，包含相似标志建议
 type UnknownFlagError struct {
 	Command     string   // 当前命令名
+	Path        string   // 当前命令路径
 	Input       string   // 用户输入的错误标志
 	Suggestions []string // 相似标志建议列表
 }
@@ -64,12 +77,354 @@ func (e *UnknownFlagError) Error() string {
 	_, _ = fmt.Fprintf(&sb, "%s: unknown flag: '%s'\n",
 		e.Command, e.Input)
 
-	if len(e.Suggestions) > 0 {
-		sb.WriteString("\nThe most similar flags are\n")
-		for _, sug := range e.Suggestions {
-			_, _ = fmt.Fprintf(&sb, "\t%s\n", sug)
-		}
+	writeCandidates(&sb, "The most similar flags are", e.Suggestions)
+
+	return sb.String()
+}
+
+// AmbiguousSubcommandError 子命令缩写歧义错误
+//
+// 开启缩写模式后, 当用户输入的前缀匹配多个子命令时返回此错误
+type AmbiguousSubcommandError struct {
+	Command    string   // 当前命令名
+	Path       string   // 当前命令路径
+	Input      string   // 用户输入的子命令前缀
+	Candidates []string // 匹配该前缀的子命令列表
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+//
+// 格式示例：
+//
+//	myapp: 'st' is ambiguous. See 'myapp --help'.
+//
+//	The possible commands are
+//	        start
+//	        status
+func (e *AmbiguousSubcommandError) Error() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: '%s' is ambiguous. See '%s --help'.\n",
+		e.Command, e.Input, e.Command)
+
+	writeCandidates(&sb, "The possible commands are", e.Candidates)
+
+	return sb.String()
+}
+
+// AmbiguousFlagError 标志缩写歧义错误
+//
+// 开启缩写模式后, 当用户输入的长标志前缀匹配多个标志时返回此错误
+type AmbiguousFlagError struct {
+	Command    string   // 当前命令名
+	Path       string   // 当前命令路径
+	Input      string   // 用户输入的标志前缀
+	Candidates []string // 匹配该前缀的标志列表
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+//
+// 格式示例：
+//
+//	myapp: ambiguous flag: '--ver'
+//
+//	The possible flags are
+//	        --verbose
+//	        --version
+func (e *AmbiguousFlagError) Error() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: ambiguous flag: '%s'\n",
+		e.Command, e.Input)
+
+	writeCandidates(&sb, "The possible flags are", e.Candidates)
+
+	return sb.String()
+}
+
+// InvalidValueError 标志值无效错误
+//
+// 当命令行参数、环境变量或配置文件中的值无法设置到标志时返回此错误,
+// 例如数字格式错误、枚举值不在可选范围内、大小单位无法识别或验证器拒绝
+type InvalidValueError struct {
+	Command  string      // 当前命令名
+	Path     string      // 当前命令路径
+	Flag     string      // 标志名称 (长名称优先)
+	Value    string      // 无效的值
+	Source   ValueSource // 值的来源 (命令行参数、环境变量或配置文件)
+	Location string      // 来源位置: 标志写法 (如 -p)、环境变量名或 "配置文件:键路径"
+	Allowed  []string    // 可选值列表, 仅枚举标志非空
+	Err      error       // 底层错误
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+//
+// 格式示例：
+//
+//	invalid value "abc" for flag --port: ...
+//	invalid value "abc" for environment variable APP_PORT: ...
+//	invalid config value for 'app.toml:server.port' in 'server': ...
+func (e *InvalidValueError) Error() string {
+	switch e.Source {
+	case SourceEnv:
+		return fmt.Sprintf("invalid value %q for environment variable %s: %v", e.Value, e.Location, e.Err)
+	case SourceConfig:
+		return fmt.Sprintf("invalid config value for '%s' in '%s': %v", e.Location, e.Command, e.Err)
+	default:
+		return fmt.Sprintf("invalid value %q for flag %s: %v", e.Value, e.Location, e.Err)
+	}
+}
+
+// Unwrap 返回底层错误
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// MissingValueError 标志缺少值错误
+//
+// 当需要值的标志出现在参数列表末尾且没有附加值时返回此错误
+type MissingValueError struct {
+	Command string // 当前命令名
+	Path    string // 当前命令路径
+	Flag    string // 标志写法, 如 --output 或 -o
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *MissingValueError) Error() string {
+	return fmt.Sprintf("flag needs an argument: %s", e.Flag)
+}
+
+// UnexpectedValueError 标志不接受值错误
+//
+// 当 --no-<name> 取反形式附带了值时返回此错误
+type UnexpectedValueError struct {
+	Command string // 当前命令名
+	Path    string // 当前命令路径
+	Flag    string // 标志写法, 如 --no-color
+	Value   string // 附带的值
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *UnexpectedValueError) Error() string {
+	return fmt.Sprintf("flag does not take a value: %s", e.Flag)
+}
+
+// MutexGroupError 互斥组违规错误
+//
+// 当互斥组中设置了多个标志, 或不允许为空的互斥组中一个都没有设置时返回此错误
+type MutexGroupError struct {
+	Command string   // 当前命令名
+	Path    string   // 当前命令路径
+	Group   string   // 互斥组名称
+	Flags   []string // NoneSet 为false时是同时设置的标志, 为true时是组内的所有标志
+	NoneSet bool     // 是否因为一个都没有设置而违规
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *MutexGroupError) Error() string {
+	if e.NoneSet {
+		return fmt.Sprintf("one of flags %v in mutex group '%s' must be set", e.Flags, e.Group)
+	}
+	return fmt.Sprintf("mutually exclusive flags %v in group '%s' cannot be used together", e.Flags, e.Group)
+}
+
+// RequiredGroupError 必需组违规错误
+//
+// 当必需组中有标志未设置时返回此错误
+type RequiredGroupError struct {
+	Command     string   // 当前命令名
+	Path        string   // 当前命令路径
+	Group       string   // 必需组名称
+	Missing     []string // 未设置的标志
+	Conditional bool     // 是否为条件性必需组
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *RequiredGroupError) Error() string {
+	if e.Conditional {
+		return fmt.Sprintf("flags %v in group '%s' must all be set", e.Missing, e.Group)
+	}
+	return fmt.Sprintf("required flags %v in group '%s' must be set", e.Missing, e.Group)
+}
+
+// DependencyError 标志依赖关系违规错误
+//
+// 当触发标志被设置, 而目标标志违反依赖约束时返回此错误
+type DependencyError struct {
+	Command    string   // 当前命令名
+	Path       string   // 当前命令路径
+	Dependency string   // 依赖关系名称
+	Trigger    string   // 触发标志
+	Type       DepType  // 依赖关系类型
+	Flags      []string // 互斥依赖时为冲突的标志, 必需依赖时为缺失的标志
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *DependencyError) Error() string {
+	if e.Type == DepMutex {
+		return fmt.Sprintf("flag %s cannot be used with %v (dependency: %s)", e.Trigger, e.Flags, e.Dependency)
+	}
+	return fmt.Sprintf("flag %s requires flags %v to be set (dependency: %s)", e.Trigger, e.Flags, e.Dependency)
+}
+
+// MissingArgumentError 缺少必需位置参数错误
+type MissingArgumentError struct {
+	Command string // 当前命令名
+	Path    string // 当前命令路径
+	Arg     string // 位置参数名称
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *MissingArgumentError) Error() string {
+	return fmt.Sprintf("missing required argument <%s> in '%s'", e.Arg, e.Command)
+}
+
+// InvalidArgumentError 位置参数值无效错误
+type InvalidArgumentError struct {
+	Command  string   // 当前命令名
+	Path     string   // 当前命令路径
+	Arg      string   // 位置参数名称
+	Values   []string // 无效的值, 可变参数时包含所有元素
+	Variadic bool     // 是否为可变参数
+	Allowed  []string // 可选值列表, 仅枚举参数非空
+	Err      error    // 底层错误
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *InvalidArgumentError) Error() string {
+	if !e.Variadic && len(e.Values) == 1 {
+		return fmt.Sprintf("invalid value %q for argument <%s>: %v", e.Values[0], e.Arg, e.Err)
+	}
+	return fmt.Sprintf("invalid value %q for argument <%s>: %v", e.Values, e.Arg, e.Err)
+}
+
+// Unwrap 返回底层错误
+func (e *InvalidArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// TooManyArgumentsError 位置参数过多错误
+type TooManyArgumentsError struct {
+	Command string // 当前命令名
+	Path    string // 当前命令路径
+	Max     int    // 最多接受的参数个数
+	Got     int    // 实际的参数个数
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *TooManyArgumentsError) Error() string {
+	return fmt.Sprintf("too many arguments in '%s': expected at most %d, got %d", e.Command, e.Max, e.Got)
+}
+
+// UnknownConfigKeyError 未知配置键错误
+//
+// 当配置文件中的键既不是标志长名称也不是子命令分区时返回此错误
+type UnknownConfigKeyError struct {
+	Command string // 当前命令名
+	Path    string // 当前命令路径
+	File    string // 配置文件路径
+	Key     string // 以 . 分隔的完整键路径
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *UnknownConfigKeyError) Error() string {
+	return fmt.Sprintf("unknown config key '%s' in '%s'", e.Key, e.Command)
+}
+
+// NoRunFuncError 命令没有设置运行函数错误
+//
+// 当路由到的命令没有设置运行函数时返回此错误
+type NoRunFuncError struct {
+	Command string // 当前命令名
+	Path    string // 当前命令路径
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *NoRunFuncError) Error() string {
+	return fmt.Sprintf("cmd %q has no run function set", e.Command)
+}
+
+// SignalError 收到终止信号错误
+//
+// 由 SignalContext 创建的上下文在收到 SIGINT/SIGTERM 时以此错误作为取消原因,
+// 可以通过 context.Cause 取出
+type SignalError struct {
+	Signal os.Signal // 收到的信号
+	Err    error     // 因信号产生的错误, 如运行函数返回的 context.Canceled, 作为取消原因时为nil
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("received signal: %v", e.Signal)
+}
+
+// Unwrap 返回因信号产生的错误
+func (e *SignalError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode 返回信号对应的惯用退出码
+//
+// 返回值:
+//   - int: 128 + 信号编号, 如 SIGINT 为 130, SIGTERM 为 143; 无法识别编号时为 ExitCodeInterrupted
+func (e *SignalError) ExitCode() int {
+	if sig, ok := e.Signal.(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return ExitCodeInterrupted
+}
+
+// ParseErrors 汇总的解析错误
+//
+// 开启错误收集模式后, 解析器在一次解析中收集所有问题 (无效值、未知标志、
+// 环境变量和配置文件错误、互斥组/必需组/依赖关系违规), 汇总为此错误返回。
+//
+// 使用方式:
+//   - 遍历 Errors 字段获取每个问题
+//   - errors.As / errors.Is 可以匹配其中任意一个错误 (通过 Unwrap() []error)
+type ParseErrors struct {
+	Command string  // 当前命令名
+	Errors  []error // 按发现顺序排列的错误列表
+}
+
+// Error 实现 error 接口，返回格式化的错误信息
+//
+// 格式示例：
+//
+//	myapp: 2 errors occurred:
+//	        invalid value "abc" for flag --port: ...
+//	        required flags [--name] in group 'base' must be set
+func (e *ParseErrors) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: %d errors occurred:\n", e.Command, len(e.Errors))
+	for _, err := range e.Errors {
+		msg := strings.TrimRight(err.Error(), "\n")
+		_, _ = fmt.Fprintf(&sb, "\t%s\n", strings.ReplaceAll(msg, "\n", "\n\t"))
 	}
 
 	return sb.String()
 }
+
+// Unwrap 返回所有单独的错误, 支持 errors.Is 和 errors.As
+func (e *ParseErrors) Unwrap() []error {
+	return e.Errors
+}
+
+// writeCandidates 写入候选项列表
+//
+// 参数:
+//   - sb: 字符串构建器
+//   - title: 列表标题
+//   - candidates: 候选项列表, 为空时不写入
+func writeCandidates(sb *strings.Builder, title string, candidates []string) {
+	if len(candidates) == 0 {
+		return
+	}
+
+	sb.WriteString("\n" + title + "\n")
+	for _, c := range candidates {
+		_, _ = fmt.Fprintf(sb, "\t%s\n", c)
+	}
+}
@@ -0,0 +1,188 @@
+// error_cn.go - 错误类型的中文错误信息
+//
+// 该文件为 error.go 中的错误类型提供中文错误信息。Error() 始终返回英文,
+// 命令开启中文 (UseChinese) 时, Execute 通过 LocalizedMessage 输出中文信息。
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LocalizedError 支持中文错误信息的错误接口
+//
+// 解析器返回的错误类型都实现了此接口, 底层错误 (如验证器返回的错误) 按原样嵌入
+type LocalizedError interface {
+	error
+
+	// ErrorCN 返回中文错误信息
+	//
+	// 返回值:
+	//   - string: 中文错误信息
+	ErrorCN() string
+}
+
+// LocalizedMessage 按语言设置获取错误信息
+//
+// 参数:
+//   - err: 要输出的错误
+//   - chinese: 是否使用中文
+//
+// 返回值:
+//   - string: 错误信息, chinese 为true且 err 实现了 LocalizedError 时为中文, 否则为 err.Error()
+//
+// 注意事项:
+//   - 只检查 err 本身, 不展开包装链; 被 fmt.Errorf 等包装后的错误保留包装后的英文信息,
+//     避免丢失调用方添加的上下文
+func LocalizedMessage(err error, chinese bool) string {
+	if chinese {
+		if le, ok := err.(LocalizedError); ok {
+			return le.ErrorCN()
+		}
+	}
+	return err.Error()
+}
+
+// ErrorCN 返回中文错误信息
+func (e *UnknownSubcommandError) ErrorCN() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: '%s' 不是有效的命令, 请查看 '%s --help'\n",
+		e.Command, e.Input, e.Command)
+
+	writeCandidates(&sb, "最相似的命令有", e.Suggestions)
+
+	return sb.String()
+}
+
+// ErrorCN 返回中文错误信息
+func (e *UnknownFlagError) ErrorCN() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: 未知标志: '%s'\n", e.Command, e.Input)
+
+	writeCandidates(&sb, "最相似的标志有", e.Suggestions)
+
+	return sb.String()
+}
+
+// ErrorCN 返回中文错误信息
+func (e *AmbiguousSubcommandError) ErrorCN() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: '%s' 有歧义, 请查看 '%s --help'\n",
+		e.Command, e.Input, e.Command)
+
+	writeCandidates(&sb, "可能的命令有", e.Candidates)
+
+	return sb.String()
+}
+
+// ErrorCN 返回中文错误信息
+func (e *AmbiguousFlagError) ErrorCN() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: 标志有歧义: '%s'\n", e.Command, e.Input)
+
+	writeCandidates(&sb, "可能的标志有", e.Candidates)
+
+	return sb.String()
+}
+
+// ErrorCN 返回中文错误信息
+func (e *InvalidValueError) ErrorCN() string {
+	switch e.Source {
+	case SourceEnv:
+		return fmt.Sprintf("环境变量 %s 的值 %q 无效: %v", e.Location, e.Value, e.Err)
+	case SourceConfig:
+		return fmt.Sprintf("'%s' 中的配置项 '%s' 的值无效: %v", e.Command, e.Location, e.Err)
+	default:
+		return fmt.Sprintf("标志 %s 的值 %q 无效: %v", e.Location, e.Value, e.Err)
+	}
+}
+
+// ErrorCN 返回中文错误信息
+func (e *MissingValueError) ErrorCN() string {
+	return fmt.Sprintf("标志缺少参数: %s", e.Flag)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *UnexpectedValueError) ErrorCN() string {
+	return fmt.Sprintf("标志不接受值: %s", e.Flag)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *MutexGroupError) ErrorCN() string {
+	if e.NoneSet {
+		return fmt.Sprintf("互斥组 '%s' 中的标志 %v 必须设置一个", e.Group, e.Flags)
+	}
+	return fmt.Sprintf("互斥组 '%s' 中的标志 %v 不能同时使用", e.Group, e.Flags)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *RequiredGroupError) ErrorCN() string {
+	if e.Conditional {
+		return fmt.Sprintf("组 '%s' 中的标志 %v 必须全部设置", e.Group, e.Missing)
+	}
+	return fmt.Sprintf("必需组 '%s' 中的标志 %v 必须设置", e.Group, e.Missing)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *DependencyError) ErrorCN() string {
+	if e.Type == DepMutex {
+		return fmt.Sprintf("标志 %s 不能与 %v 同时使用 (依赖关系: %s)", e.Trigger, e.Flags, e.Dependency)
+	}
+	return fmt.Sprintf("标志 %s 需要同时设置 %v (依赖关系: %s)", e.Trigger, e.Flags, e.Dependency)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *MissingArgumentError) ErrorCN() string {
+	return fmt.Sprintf("'%s' 缺少必需的参数 <%s>", e.Command, e.Arg)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *InvalidArgumentError) ErrorCN() string {
+	if !e.Variadic && len(e.Values) == 1 {
+		return fmt.Sprintf("参数 <%s> 的值 %q 无效: %v", e.Arg, e.Values[0], e.Err)
+	}
+	return fmt.Sprintf("参数 <%s> 的值 %q 无效: %v", e.Arg, e.Values, e.Err)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *TooManyArgumentsError) ErrorCN() string {
+	return fmt.Sprintf("'%s' 的参数过多: 最多 %d 个, 实际 %d 个", e.Command, e.Max, e.Got)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *UnknownConfigKeyError) ErrorCN() string {
+	return fmt.Sprintf("'%s' 中的配置键 '%s' 未知", e.Command, e.Key)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *NoRunFuncError) ErrorCN() string {
+	return fmt.Sprintf("命令 %q 没有设置运行函数", e.Command)
+}
+
+// ErrorCN 返回中文错误信息
+func (e *SignalError) ErrorCN() string {
+	return fmt.Sprintf("收到信号: %v", e.Signal)
+}
+
+// ErrorCN 返回中文错误信息
+//
+// 格式示例：
+//
+//	myapp: 发生 2 个错误:
+//	        标志 --port 的值 "abc" 无效: ...
+//	        必需组 'base' 中的标志 [--name] 必须设置
+func (e *ParseErrors) ErrorCN() string {
+	if len(e.Errors) == 1 {
+		return LocalizedMessage(e.Errors[0], true)
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s: 发生 %d 个错误:\n", e.Command, len(e.Errors))
+	for _, err := range e.Errors {
+		msg := strings.TrimRight(LocalizedMessage(err, true), "\n")
+		_, _ = fmt.Fprintf(&sb, "\t%s\n", strings.ReplaceAll(msg, "\n", "\n\t"))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// 惯用的进程退出码
+const (
+	// ExitCodeOK 执行成功, 或用户请求了帮助、版本、补全等内置功能
+	ExitCodeOK = 0
+
+	// ExitCodeFailure 运行函数或钩子执行失败
+	ExitCodeFailure = 1
+
+	// ExitCodeUsage 命令调用方式错误 (未知标志或子命令、缺少参数值、参数个数不符等), 与标准库 flag 包一致
+	ExitCodeUsage = 2
+
+	// ExitCodeTimeout 执行超时 (上下文超过截止时间), 与 timeout(1) 一致
+	ExitCodeTimeout = 124
+
+	// ExitCodeInterrupted 执行被中断 (收到 SIGINT 或上下文被取消), 即 128 + SIGINT
+	ExitCodeInterrupted = 130
+)
+
+// ExitCoder 携带退出码的错误
+//
+// 运行函数返回实现了该接口的错误时, 使用错误自身的退出码退出程序
+type ExitCoder interface {
+	error
+	ExitCode() int // 进程退出码
+}
+
+// IsUsageError 检查错误是否为命令调用方式错误
+//
+// 参数:
+//   - err: 要检查的错误
+//
+// 返回值:
+//   - bool: 未知或有歧义的标志和子命令、缺少或多余的标志值、缺少或多余的位置参数、
+//     未知的配置文件键、命令没有运行函数时返回true
+func IsUsageError(err error) bool {
+	var (
+		unknownSub *UnknownSubcommandError
+		unknownFlg *UnknownFlagError
+		ambigSub   *AmbiguousSubcommandError
+		ambigFlg   *AmbiguousFlagError
+		missingVal *MissingValueError
+		unexpected *UnexpectedValueError
+		missingArg *MissingArgumentError
+		tooMany    *TooManyArgumentsError
+		unknownKey *UnknownConfigKeyError
+		noRun      *NoRunFuncError
+	)
+	return errors.As(err, &unknownSub) || errors.As(err, &unknownFlg) ||
+		errors.As(err, &ambigSub) || errors.As(err, &ambigFlg) ||
+		errors.As(err, &missingVal) || errors.As(err, &unexpected) ||
+		errors.As(err, &missingArg) || errors.As(err, &tooMany) ||
+		errors.As(err, &unknownKey) || errors.As(err, &noRun)
+}
+
+// IsValidationError 检查错误是否为值验证错误
+//
+// 参数:
+//   - err: 要检查的错误
+//
+// 返回值:
+//   - bool: 标志或位置参数的值无效 (类型转换或验证器失败)、
+//     违反互斥组/必需组/依赖关系规则时返回true
+func IsValidationError(err error) bool {
+	var (
+		invalidVal *InvalidValueError
+		invalidArg *InvalidArgumentError
+		mutex      *MutexGroupError
+		required   *RequiredGroupError
+		dependency *DependencyError
+	)
+	return errors.As(err, &invalidVal) || errors.As(err, &invalidArg) ||
+		errors.As(err, &mutex) || errors.As(err, &required) ||
+		errors.As(err, &dependency)
+}
+
+// ExitCodeOf 将解析或执行返回的错误映射为进程退出码
+//
+// 参数:
+//   - err: ParseAndRoute 等方法返回的错误
+//   - validationCode: 值验证错误使用的退出码
+//
+// 返回值:
+//   - int: 退出码
+//
+// 映射规则 (按顺序匹配):
+//   - nil 或 ErrHelp/ErrVersion/ErrCompletion: ExitCodeOK (0)
+//   - 实现了 ExitCoder 的错误 (包括 *SignalError): 错误自身的退出码
+//   - 超过截止时间: ExitCodeTimeout (124); 上下文被取消: ExitCodeInterrupted (130)
+//   - 调用方式错误 (见 IsUsageError): ExitCodeUsage (2)
+//   - 值验证错误 (见 IsValidationError): validationCode
+//   - 其他错误: ExitCodeFailure (1)
+//
+// 注意事项:
+//   - 汇总的 *ParseErrors 中只要包含调用方式错误即视为调用方式错误
+//   - 单独的 context.Canceled 不带信号信息, 只能映射为 ExitCodeInterrupted;
+//     Execute 会先从上下文的取消原因中补充 *SignalError, 使 SIGTERM 映射为 143
+func ExitCodeOf(err error, validationCode int) int {
+	if err == nil || errors.Is(err, ErrHelp) || errors.Is(err, ErrVersion) || errors.Is(err, ErrCompletion) {
+		return ExitCodeOK
+	}
+
+	var coder ExitCoder
+	switch {
+	case errors.As(err, &coder):
+		return coder.ExitCode()
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitCodeTimeout
+	case errors.Is(err, context.Canceled):
+		return ExitCodeInterrupted
+	case IsUsageError(err):
+		return ExitCodeUsage
+	case IsValidationError(err):
+		return validationCode
+	default:
+		return ExitCodeFailure
+	}
+}
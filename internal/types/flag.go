@@ -91,8 +91,14 @@ const (
 	FlagTypeStringSlice // 字符串切片标志, 字符串数组
 	FlagTypeIntSlice    // 整数切片标志, 整数数组
 	FlagTypeInt64Slice  // 64位整数切片标志, 64位整数数组
+
+	// 计数类型
+	FlagTypeCount // 计数标志, 每出现一次加一
 )
 
+// NegatePrefix 布尔标志取反形式的名称前缀, 如 --no-color
+const NegatePrefix = "no-"
+
 // String 返回标志类型的字符串表示
 //
 // 返回值:
@@ -147,6 +153,8 @@ func (t FlagType) String() string {
 		return "[]int64"
 	case FlagTypeSize:
 		return "size"
+	case FlagTypeCount:
+		return "count"
 	default:
 		return fmt.Sprintf("FlagType(%d)", t)
 	}
@@ -173,6 +181,7 @@ func (t FlagType) String() string {
 // 空值处理:
 //   - StringFlag: 空字符串不经过验证器，直接设置
 //   - BoolFlag: 不经过验证器（无空值概念）
+//   - CountFlag: 空字符串表示计数加一, 验证器作用于加一后的值
 //   - 集合类型 (MapFlag, StringSliceFlag, IntSliceFlag, Int64SliceFlag): 空字符串不经过验证器，创建空集合
 //   - 其他类型: 空字符串直接返回错误，不经过验证器
 //
@@ -223,7 +232,7 @@ func (t FlagType) IsSliceType() bool {
 func (t FlagType) IsNumericType() bool {
 	switch t {
 	case FlagTypeInt, FlagTypeInt64, FlagTypeUint, FlagTypeUint16, FlagTypeUint32, FlagTypeUint64,
-		FlagTypeFloat64, FlagTypeSize:
+		FlagTypeFloat64, FlagTypeSize, FlagTypeCount:
 		return true
 	default:
 		return false
@@ -408,4 +417,99 @@ type Flag interface {
 	//   - 枚举类型返回所有可选值
 	//   - 用于补全脚本生成和验证
 	EnumValues() []string
+
+	// IsNegatable 检查标志是否支持取反形式
+	//
+	// 返回值:
+	//   - bool: 支持 --no-<name> 形式时返回true
+	//
+	// 功能说明:
+	//   - 仅布尔标志可以开启取反形式
+	//   - 用于解析器、纠错建议、帮助信息和补全脚本生成
+	IsNegatable() bool
+
+	// ImplicitValue 获取标志的隐式值
+	//
+	// 返回值:
+	//   - string: 标志不带值出现时使用的值
+	//   - bool: 是否设置了隐式值
+	//
+	// 功能说明:
+	//   - 设置了隐式值的标志可以不带值出现, 如 --color 等同于 --color=always
+	//   - 显式的值必须使用 --name=value 或 -svalue 形式, 不会取走下一个参数
+	//   - 用于解析器、帮助信息和补全脚本生成
+	ImplicitValue() (string, bool)
+
+	// CompletionFunc 获取标志值的动态补全函数
+	//
+	// 返回值:
+	//   - CompletionFunc: 补全函数, 未设置时返回nil
+	//
+	// 功能说明:
+	//   - 动态补全标志值或位置参数值时调用, 返回运行时计算的候选项
+	//   - 设置后优先于枚举值补全
+	CompletionFunc() CompletionFunc
+
+	// ValueHint 获取标志值的类型提示
+	//
+	// 返回值:
+	//   - ValueHint: 值类型提示, 未声明时 Kind 为 ValueKindAuto
+	//
+	// 功能说明:
+	//   - 返回通过 SetValueHint 声明的值类型
+	//   - 用于静态和动态补全脚本补全文件、目录和主机名
+	ValueHint() ValueHint
+
+	// Origin 获取标志当前值的来源信息
+	//
+	// 返回值:
+	//   - ValueOrigin: 来源、原始字符串和来源位置
+	//
+	// 功能说明:
+	//   - 未设置的标志返回 SourceDefault, Raw 为默认值的字符串形式
+	//   - 解析器在设置命令行参数、环境变量和配置文件的值时记录来源
+	//   - 已设置但没有记录来源的标志 (如程序中直接调用 Set) 返回 SourceProgram
+	Origin() ValueOrigin
+
+	// SetOrigin 记录标志当前值的来源信息
+	//
+	// 参数:
+	//   - origin: 来源信息
+	//
+	// 功能说明:
+	//   - 由解析器在成功设置值之后调用
+	//   - Reset 会清除记录的来源
+	SetOrigin(origin ValueOrigin)
+}
+
+// Accumulator 可累积标志接口
+//
+// Accumulator 由切片和映射类型的标志实现。开启累积模式后, 解析器对
+// 命令行中重复出现的标志调用 Append 而不是 Set:
+//   - 第一次出现时替换默认值或环境变量值
+//   - 之后的出现追加元素或合并键值对
+//
+// Append 不执行验证器, 解析器在命令行解析完成后对每个累积过的标志
+// 调用一次 Validate, 验证器作用于合并后的完整值。
+type Accumulator interface {
+	// IsAccumulate 检查是否开启累积模式
+	//
+	// 返回值:
+	//   - bool: 是否开启累积模式
+	IsAccumulate() bool
+
+	// Append 累积设置标志的值
+	//
+	// 参数:
+	//   - value: 要追加或合并的字符串值
+	//
+	// 返回值:
+	//   - error: 解析失败时返回错误
+	Append(value string) error
+
+	// Validate 验证累积后的完整值
+	//
+	// 返回值:
+	//   - error: 验证失败时返回错误
+	Validate() error
 }
@@ -26,24 +26,28 @@ type SubCmdInfo struct {
 
 // 帮助信息标题 - 中文
 const (
-	HelpNameCN     = "名称:\n"
-	HelpDescCN     = "\n描述:\n"
-	HelpUsageCN    = "\n用法:\n"
-	HelpOptionsCN  = "\n选项:\n"
-	HelpSubCmdsCN  = "\n子命令:\n"
-	HelpExamplesCN = "\n示例:\n"
-	HelpNotesCN    = "\n注意:\n"
+	HelpNameCN          = "名称:\n"
+	HelpDescCN          = "\n描述:\n"
+	HelpUsageCN         = "\n用法:\n"
+	HelpArgumentsCN     = "\n参数:\n"
+	HelpOptionsCN       = "\n选项:\n"
+	HelpGlobalOptionsCN = "\n全局选项:\n"
+	HelpSubCmdsCN       = "\n子命令:\n"
+	HelpExamplesCN      = "\n示例:\n"
+	HelpNotesCN         = "\n注意:\n"
 )
 
 // 帮助信息标题 - 英文
 const (
-	HelpNameEN     = "Name:\n"
-	HelpDescEN     = "\nDesc:\n"
-	HelpUsageEN    = "\nUsage:\n"
-	HelpOptionsEN  = "\nOptions:\n"
-	HelpSubCmdsEN  = "\nSubcmds:\n"
-	HelpExamplesEN = "\nExamples:\n"
-	HelpNotesEN    = "\nNotes:\n"
+	HelpNameEN          = "Name:\n"
+	HelpDescEN          = "\nDesc:\n"
+	HelpUsageEN         = "\nUsage:\n"
+	HelpArgumentsEN     = "\nArguments:\n"
+	HelpOptionsEN       = "\nOptions:\n"
+	HelpGlobalOptionsEN = "\nGlobal Options:\n"
+	HelpSubCmdsEN       = "\nSubcmds:\n"
+	HelpExamplesEN      = "\nExamples:\n"
+	HelpNotesEN         = "\nNotes:\n"
 )
 
 // 统一的前缀, 缩进两个空格
@@ -0,0 +1,60 @@
+package types
+
+// HookType 生命周期钩子类型
+//
+// ParseAndRoute 路由到最终执行的命令后, 按以下顺序执行钩子和运行函数:
+//  1. 从根命令到当前命令, 依次执行每个命令的 PersistentPreRun
+//  2. 当前命令的 PreRun
+//  3. 当前命令的 Run
+//  4. 当前命令的 PostRun
+//  5. 从当前命令到根命令, 依次执行每个命令的 PersistentPostRun
+type HookType int
+
+const (
+	// PersistentPreRunHook 持久前置钩子, 在所有子孙命令 (包括自身) 运行前执行
+	PersistentPreRunHook HookType = iota
+
+	// PreRunHook 前置钩子, 仅在自身运行前执行
+	PreRunHook
+
+	// PostRunHook 后置钩子, 仅在自身运行后执行
+	PostRunHook
+
+	// PersistentPostRunHook 持久后置钩子, 在所有子孙命令 (包括自身) 运行后执行
+	PersistentPostRunHook
+)
+
+// String 返回钩子类型的名称
+//
+// 返回值:
+//   - string: 钩子类型名称
+func (h HookType) String() string {
+	switch h {
+	case PersistentPreRunHook:
+		return "PersistentPreRun"
+	case PreRunHook:
+		return "PreRun"
+	case PostRunHook:
+		return "PostRun"
+	case PersistentPostRunHook:
+		return "PersistentPostRun"
+	default:
+		return "Unknown"
+	}
+}
+
+// RunFunc 命令运行函数
+//
+// 参数为路由到的最终命令, 可通过它访问 Path()、Flags()、Args() 等解析结果
+type RunFunc func(Command) error
+
+// Middleware 运行函数中间件
+//
+// 中间件接收下一个运行函数 next, 返回包装后的运行函数。
+// 在包装函数中调用 next 即继续执行后续中间件和命令的运行函数,
+// 不调用 next 则跳过运行函数 (例如功能开关)。
+//
+// ParseAndRoute 执行时, 路由路径上从根命令到当前命令注册的中间件依次由外向内包装
+// 当前命令的运行函数, 即根命令的中间件最先进入、最后退出。
+// 中间件只包装运行函数, PreRun/PostRun 等生命周期钩子在中间件链之外执行。
+type Middleware func(next RunFunc) RunFunc
@@ -0,0 +1,65 @@
+package types
+
+// ValueSource 标志值的来源
+//
+// ValueSource 记录标志最终值来自哪里, 用于输出生效配置和排查优先级问题。
+// 优先级从高到低: 命令行参数 > 环境变量 > 配置文件 > 默认值。
+type ValueSource int
+
+const (
+	// SourceDefault 默认值, 标志未被设置
+	SourceDefault ValueSource = iota
+
+	// SourceCLI 命令行参数
+	SourceCLI
+
+	// SourceEnv 环境变量
+	SourceEnv
+
+	// SourceConfig 配置文件
+	SourceConfig
+
+	// SourceProgram 程序中直接调用 Set 等方法设置
+	SourceProgram
+)
+
+// String 返回值来源的字符串表示
+func (s ValueSource) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceCLI:
+		return "cli"
+	case SourceEnv:
+		return "env"
+	case SourceConfig:
+		return "config"
+	case SourceProgram:
+		return "program"
+	default:
+		return "unknown"
+	}
+}
+
+// ValueOrigin 标志值的来源信息
+//
+// 字段说明:
+//   - Source: 值的来源
+//   - Raw: 设置值时使用的原始字符串; 默认值为默认值的字符串形式
+//   - Location: 来源位置, 命令行参数为标志写法 (如 --name 或 -n),
+//     环境变量为完整的变量名, 配置文件为 "文件路径:键路径" (如 app.toml:server.address),
+//     默认值和程序设置为空
+type ValueOrigin struct {
+	Source   ValueSource // 值的来源
+	Raw      string      // 原始字符串
+	Location string      // 来源位置
+}
+
+// FlagOrigin 命令中一个标志及其值来源
+//
+// 由 Cmd.FlagOrigins 返回, 用于列出命令所有标志的生效值和来源
+type FlagOrigin struct {
+	Flag      Flag        // 标志
+	Inherited bool        // 是否为从祖先命令继承的持久标志
+	Origin    ValueOrigin // 值的来源信息
+}
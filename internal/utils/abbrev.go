@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// AbbrevEnabled 检查命令是否允许缩写
+//
+// 参数:
+//   - cmd: 要检查的命令
+//
+// 返回值:
+//   - bool: 命令自身或任一祖先命令开启了缩写模式时返回true
+func AbbrevEnabled(cmd types.Command) bool {
+	return inheritedOption(cmd, func(cfg *types.CmdConfig) bool { return cfg.AllowAbbrev })
+}
+
+// ResolveSubCmd 根据名称或唯一前缀查找子命令
+//
+// 参数:
+//   - cmd: 父命令
+//   - name: 用户输入的子命令名称
+//
+// 返回值:
+//   - types.Command: 匹配到的子命令, 未匹配或有歧义时为nil
+//   - []string: 前缀匹配多个子命令时的候选名称列表 (已排序)
+//
+// 注意事项:
+//   - 完整名称 (长名称或短名称) 始终优先
+//   - 只有开启缩写模式时才进行前缀匹配, 隐藏命令不参与前缀匹配
+func ResolveSubCmd(cmd types.Command, name string) (types.Command, []string) {
+	if sub, ok := cmd.GetSubCmd(name); ok {
+		return sub, nil
+	}
+	if name == "" || !AbbrevEnabled(cmd) {
+		return nil, nil
+	}
+
+	var matches []types.Command
+	for _, sub := range cmd.SubCmds() {
+		if strings.HasPrefix(sub.LongName(), name) || strings.HasPrefix(sub.ShortName(), name) {
+			matches = append(matches, sub)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, sub := range matches {
+		candidates = append(candidates, sub.Name())
+	}
+	sort.Strings(candidates)
+	return nil, candidates
+}
+
+// MatchPrefix 查找以指定前缀开头的名称
+//
+// 参数:
+//   - names: 候选名称列表
+//   - prefix: 前缀
+//
+// 返回值:
+//   - []string: 匹配的名称列表 (已排序, 已去重)
+func MatchPrefix(names []string, prefix string) []string {
+	seen := make(map[string]bool, len(names))
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) && !seen[name] {
+			seen[name] = true
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
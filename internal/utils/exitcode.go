@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// executeKey 标记由 Execute 接管错误处理的上下文键
+type executeKey struct{}
+
+// executeState Execute 执行期间记录的状态
+type executeState struct {
+	routed types.Command // 最近一次路由到的命令
+}
+
+// WithExecute 返回一个标记为由 Execute 接管错误处理的上下文
+//
+// 参数:
+//   - parent: 父上下文
+//
+// 返回值:
+//   - context.Context: 带有标记的上下文
+//
+// 功能说明:
+//   - 解析器在该上下文下不输出解析错误, 也不按错误处理策略退出或 panic,
+//     由 Execute 统一输出错误信息并按退出码退出
+//   - 上下文同时记录路由到的命令, 见 MarkRouted 和 RoutedCommand
+func WithExecute(parent context.Context) context.Context {
+	return context.WithValue(parent, executeKey{}, &executeState{})
+}
+
+// Executing 检查命令是否在 Execute 中执行
+//
+// 参数:
+//   - cmd: 要检查的命令
+//
+// 返回值:
+//   - bool: 命令的执行上下文由 WithExecute 创建时返回true
+func Executing(cmd types.Command) bool {
+	_, ok := cmd.Context().Value(executeKey{}).(*executeState)
+	return ok
+}
+
+// MarkRouted 记录 Execute 当前路由到的命令
+//
+// 参数:
+//   - cmd: 正在解析的命令
+//
+// 注意事项:
+//   - 命令不在 Execute 中执行时不做任何处理
+//   - 解析器每进入一层命令调用一次, 最后记录的即为返回错误的命令
+func MarkRouted(cmd types.Command) {
+	if state, ok := cmd.Context().Value(executeKey{}).(*executeState); ok {
+		state.routed = cmd
+	}
+}
+
+// RoutedCommand 获取 Execute 最近一次路由到的命令
+//
+// 参数:
+//   - ctx: 由 WithExecute 创建的上下文
+//   - fallback: 未记录命令时返回的命令
+//
+// 返回值:
+//   - types.Command: 最近一次通过 MarkRouted 记录的命令, 未记录时返回 fallback
+func RoutedCommand(ctx context.Context, fallback types.Command) types.Command {
+	if state, ok := ctx.Value(executeKey{}).(*executeState); ok && state.routed != nil {
+		return state.routed
+	}
+	return fallback
+}
+
+// ValidationExitCode 获取命令的值验证错误退出码
+//
+// 参数:
+//   - cmd: 要查询的命令
+//
+// 返回值:
+//   - int: 命令自身或最近的祖先命令设置的退出码, 都未设置时返回 types.ExitCodeUsage
+func ValidationExitCode(cmd types.Command) int {
+	if code := inheritedValue(cmd, func(cfg *types.CmdConfig) int { return cfg.ValidationExitCode }); code != 0 {
+		return code
+	}
+	return types.ExitCodeUsage
+}
+
+// ExitCode 将命令解析或执行返回的错误映射为进程退出码
+//
+// 参数:
+//   - cmd: 返回错误的命令 (在 Execute 中为路由到的子命令, 见 RoutedCommand)
+//   - err: 解析或执行返回的错误
+//
+// 返回值:
+//   - int: 退出码, 映射规则见 types.ExitCodeOf
+func ExitCode(cmd types.Command, err error) int {
+	return types.ExitCodeOf(err, ValidationExitCode(cmd))
+}
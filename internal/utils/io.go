@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"io"
+	"os"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// Stdout 获取命令的标准输出
+//
+// 参数:
+//   - cmd: 要查询的命令
+//
+// 返回值:
+//   - io.Writer: 命令自身或最近的祖先命令设置的标准输出, 都未设置时返回 os.Stdout
+func Stdout(cmd types.Command) io.Writer {
+	if w := inheritedValue(cmd, func(cfg *types.CmdConfig) io.Writer { return cfg.Out }); w != nil {
+		return w
+	}
+	return os.Stdout
+}
+
+// Stderr 获取命令的标准错误输出
+//
+// 参数:
+//   - cmd: 要查询的命令
+//
+// 返回值:
+//   - io.Writer: 命令自身或最近的祖先命令设置的标准错误输出, 都未设置时返回 os.Stderr
+func Stderr(cmd types.Command) io.Writer {
+	if w := inheritedValue(cmd, func(cfg *types.CmdConfig) io.Writer { return cfg.Err }); w != nil {
+		return w
+	}
+	return os.Stderr
+}
+
+// Stdin 获取命令的标准输入
+//
+// 参数:
+//   - cmd: 要查询的命令
+//
+// 返回值:
+//   - io.Reader: 命令自身或最近的祖先命令设置的标准输入, 都未设置时返回 os.Stdin
+func Stdin(cmd types.Command) io.Reader {
+	if r := inheritedValue(cmd, func(cfg *types.CmdConfig) io.Reader { return cfg.In }); r != nil {
+		return r
+	}
+	return os.Stdin
+}
@@ -0,0 +1,62 @@
+package utils
+
+import "gitee.com/MM-Q/qflag/internal/types"
+
+// ResponseFilesEnabled 检查命令是否允许展开响应文件
+//
+// 参数:
+//   - cmd: 要检查的命令
+//
+// 返回值:
+//   - bool: 命令自身或任一祖先命令开启了响应文件展开时返回true
+func ResponseFilesEnabled(cmd types.Command) bool {
+	return inheritedOption(cmd, func(cfg *types.CmdConfig) bool { return cfg.ResponseFiles })
+}
+
+// CollectErrorsEnabled 检查命令是否开启错误收集模式
+//
+// 参数:
+//   - cmd: 要检查的命令
+//
+// 返回值:
+//   - bool: 命令自身或任一祖先命令开启了错误收集模式时返回true
+func CollectErrorsEnabled(cmd types.Command) bool {
+	return inheritedOption(cmd, func(cfg *types.CmdConfig) bool { return cfg.CollectErrors })
+}
+
+// inheritedOption 检查命令自身或任一祖先命令是否开启了指定选项
+//
+// 参数:
+//   - cmd: 要检查的命令
+//   - enabled: 从命令配置中读取选项的函数
+//
+// 返回值:
+//   - bool: 任一命令开启了该选项时返回true
+func inheritedOption(cmd types.Command, enabled func(*types.CmdConfig) bool) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if cfg := c.Config(); cfg != nil && enabled(cfg) {
+			return true
+		}
+	}
+	return false
+}
+
+// inheritedValue 查找命令自身或最近的祖先命令设置的值
+//
+// 参数:
+//   - cmd: 要查询的命令
+//   - value: 从命令配置中读取值的函数
+//
+// 返回值:
+//   - T: 最近一个非零值, 都未设置时返回零值
+func inheritedValue[T comparable](cmd types.Command, value func(*types.CmdConfig) T) T {
+	var zero T
+	for c := cmd; c != nil; c = c.Parent() {
+		if cfg := c.Config(); cfg != nil {
+			if v := value(cfg); v != zero {
+				return v
+			}
+		}
+	}
+	return zero
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gitee.com/MM-Q/qflag/internal/types"
+)
+
+// SignalContext 创建一个在收到 SIGINT/SIGTERM 时取消的上下文
+//
+// 参数:
+//   - parent: 父上下文
+//
+// 返回值:
+//   - context.Context: 收到信号或父上下文结束时取消的上下文
+//   - context.CancelFunc: 停止监听信号并取消上下文, 使用完毕后必须调用
+//
+// 注意事项:
+//   - 收到信号时以 *types.SignalError 作为取消原因, 可以通过 context.Cause 取出
+//   - 只处理第一个信号, 之后的信号按系统默认行为处理 (再次 Ctrl-C 可强制退出)
+func SignalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-ch:
+			signal.Stop(ch)
+			cancel(&types.SignalError{Signal: sig})
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(ch)
+		cancel(context.Canceled)
+	}
+}
+
+// ContextExitCode 将上下文的取消原因映射为惯用的退出码
+//
+// 参数:
+//   - ctx: 执行时使用的上下文
+//
+// 返回值:
+//   - int: 退出码, 上下文未结束时为0
+//   - bool: 上下文已结束时返回true
+//
+// 映射规则:
+//   - 收到信号: 128 + 信号编号 (SIGINT 为 130, SIGTERM 为 143)
+//   - 超过截止时间: types.ExitCodeTimeout (124)
+//   - 其他取消: types.ExitCodeInterrupted (130)
+func ContextExitCode(ctx context.Context) (int, bool) {
+	if ctx.Err() == nil {
+		return 0, false
+	}
+
+	cause := context.Cause(ctx)
+	var sigErr *types.SignalError
+	switch {
+	case errors.As(cause, &sigErr):
+		return sigErr.ExitCode(), true
+	case errors.Is(cause, context.DeadlineExceeded):
+		return types.ExitCodeTimeout, true
+	default:
+		return types.ExitCodeInterrupted, true
+	}
+}
+
+// SignalCause 为执行返回的取消错误附加上下文记录的信号
+//
+// 参数:
+//   - ctx: 执行时使用的上下文
+//   - err: 解析或执行返回的错误
+//
+// 返回值:
+//   - error: 上下文因信号取消且 err 为 context.Canceled 时, 返回包装 err 的 *types.SignalError;
+//     否则原样返回 err
+//
+// 功能说明:
+//   - 运行函数通常直接返回 ctx.Err(), 只能得到 context.Canceled, 无法区分 SIGINT 和 SIGTERM
+//   - 附加信号后 types.ExitCodeOf 按收到的信号映射退出码 (SIGINT 为 130, SIGTERM 为 143),
+//     errors.Is(err, context.Canceled) 仍然成立
+func SignalCause(ctx context.Context, err error) error {
+	var coder types.ExitCoder
+	if err == nil || errors.As(err, &coder) || !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var sigErr *types.SignalError
+	if !errors.As(context.Cause(ctx), &sigErr) {
+		return err
+	}
+	return &types.SignalError{Signal: sigErr.Signal, Err: err}
+}
@@ -59,7 +59,7 @@ func FormatDefaultValue(flagType types.FlagType, defValue any) string {
 		}
 		return fmt.Sprintf("%v", defValue)
 
-	case types.FlagTypeInt, types.FlagTypeInt64, types.FlagTypeUint, types.FlagTypeUint8, types.FlagTypeUint16, types.FlagTypeUint32, types.FlagTypeUint64:
+	case types.FlagTypeInt, types.FlagTypeInt64, types.FlagTypeUint, types.FlagTypeUint8, types.FlagTypeUint16, types.FlagTypeUint32, types.FlagTypeUint64, types.FlagTypeCount:
 		return fmt.Sprintf("%d", defValue)
 
 	case types.FlagTypeFloat64:
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"context"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -755,3 +757,36 @@ func contains(s, substr string) bool {
 				return false
 			}())))
 }
+
+// TestContextExitCode 测试上下文取消原因到退出码的映射
+func TestContextExitCode(t *testing.T) {
+	if code, done := ContextExitCode(context.Background()); done || code != 0 {
+		t.Errorf("active context: got (%d, %v), want (0, false)", code, done)
+	}
+
+	sigCtx, cancel := context.WithCancelCause(context.Background())
+	cancel(&types.SignalError{Signal: syscall.SIGTERM})
+	if code, _ := ContextExitCode(sigCtx); code != 128+int(syscall.SIGTERM) {
+		t.Errorf("signal: got %d, want %d", code, 128+int(syscall.SIGTERM))
+	}
+
+	timeoutCtx, stop := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer stop()
+	<-timeoutCtx.Done()
+	if code, _ := ContextExitCode(timeoutCtx); code != types.ExitCodeTimeout {
+		t.Errorf("timeout: got %d, want %d", code, types.ExitCodeTimeout)
+	}
+
+	cancelCtx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+	if code, _ := ContextExitCode(cancelCtx); code != types.ExitCodeInterrupted {
+		t.Errorf("cancel: got %d, want %d", code, types.ExitCodeInterrupted)
+	}
+
+	// SignalContext 的停止函数以普通取消结束上下文
+	ctx, stopSignal := SignalContext(context.Background())
+	stopSignal()
+	if code, done := ContextExitCode(ctx); !done || code != types.ExitCodeInterrupted {
+		t.Errorf("stopped signal context: got (%d, %v)", code, done)
+	}
+}
@@ -1,12 +1,21 @@
 package qflag
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"gitee.com/MM-Q/qflag/internal/cmd"
+	"gitee.com/MM-Q/qflag/internal/flag"
 	"gitee.com/MM-Q/qflag/internal/types"
+	"gitee.com/MM-Q/qflag/internal/utils"
+	"gitee.com/MM-Q/qflag/validators"
 )
 
 func TestParser_IntFlag(t *testing.T) {
@@ -293,3 +302,1213 @@ func TestParser_ResetOnRepeatedParse(t *testing.T) {
 		}
 	})
 }
+
+func TestParser_GNUStyleArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		verbose  bool
+		extract  bool
+		file     string
+		port     int
+		wantArgs []string
+	}{
+		{"组合短标志", []string{"-vx"}, true, true, "", 0, []string{}},
+		{"组合短标志携带下一个参数", []string{"-vxf", "a.tar"}, true, true, "a.tar", 0, []string{}},
+		{"组合短标志携带附加值", []string{"-vfa.tar"}, true, false, "a.tar", 0, []string{}},
+		{"短标志附加值", []string{"-p8080"}, false, false, "", 8080, []string{}},
+		{"短标志等号形式", []string{"-p=8080"}, false, false, "", 8080, []string{}},
+		{"长标志等号形式", []string{"--file=b.tar", "--port", "9090"}, false, false, "b.tar", 9090, []string{}},
+		{"双横杠终止解析", []string{"-v", "--", "-x", "pos"}, true, false, "", 0, []string{"-x", "pos"}},
+		{"单横杠为位置参数", []string{"-v", "-", "rest"}, true, false, "", 0, []string{"-", "rest"}},
+		{"值可以以横杠开头", []string{"--file", "-x"}, false, false, "-x", 0, []string{}},
+		{"单横杠长名称兼容", []string{"-verbose", "-extract"}, true, true, "", 0, []string{}},
+		{"单横杠长名称等号形式", []string{"-verbose=false", "-extract=true"}, false, true, "", 0, []string{}},
+		{"组合短标志优先于单横杠长名称", []string{"-file"}, false, false, "ile", 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cmd.NewCmd("test", "t", types.ContinueOnError)
+			verbose := c.Bool("verbose", "v", "详细输出", false)
+			extract := c.Bool("extract", "x", "解压", false)
+			file := c.String("file", "f", "文件", "")
+			port := c.Int("port", "p", "端口", 0)
+
+			if err := c.Parse(tt.args); err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+
+			if verbose.Get() != tt.verbose || extract.Get() != tt.extract {
+				t.Errorf("bool flags: got verbose=%v extract=%v", verbose.Get(), extract.Get())
+			}
+			if file.Get() != tt.file {
+				t.Errorf("file: expected %q, got %q", tt.file, file.Get())
+			}
+			if port.Get() != tt.port {
+				t.Errorf("port: expected %d, got %d", tt.port, port.Get())
+			}
+			if len(c.Args()) != len(tt.wantArgs) {
+				t.Fatalf("args: expected %v, got %v", tt.wantArgs, c.Args())
+			}
+			for i, a := range tt.wantArgs {
+				if c.Args()[i] != a {
+					t.Errorf("args[%d]: expected %q, got %q", i, a, c.Args()[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParser_GNUStyleArgsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantUnknown string
+	}{
+		{"组合中的未知短标志", []string{"-vq"}, "-q"},
+		{"未知长标志", []string{"--verbos"}, "--verbos"},
+		{"单横杠非长名称", []string{"-verbos"}, "-e"},
+		{"缺少值", []string{"--file"}, ""},
+		{"组合末尾缺少值", []string{"-vf"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cmd.NewCmd("test", "t", types.ContinueOnError)
+			c.Bool("verbose", "v", "详细输出", false)
+			c.String("file", "f", "文件", "")
+
+			err := c.Parse(tt.args)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			unknownErr, ok := err.(*types.UnknownFlagError)
+			if tt.wantUnknown == "" {
+				if ok {
+					t.Errorf("expected missing value error, got %v", err)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected UnknownFlagError, got %T: %v", err, err)
+			}
+			if unknownErr.Input != tt.wantUnknown {
+				t.Errorf("input: expected %q, got %q", tt.wantUnknown, unknownErr.Input)
+			}
+		})
+	}
+}
+
+func TestParser_Interspersed(t *testing.T) {
+	tests := []struct {
+		name         string
+		interspersed bool
+		args         []string
+		race         bool
+		output       string
+		wantArgs     []string
+	}{
+		{"默认在位置参数处停止", false, []string{"./pkg", "--race"}, false, "", []string{"./pkg", "--race"}},
+		{"默认不检查位置参数之后的标志", false, []string{"./pkg", "--unknown"}, false, "", []string{"./pkg", "--unknown"}},
+		{"交替模式解析位置参数之后的标志", true, []string{"./pkg", "--race", "./cmd"}, true, "", []string{"./pkg", "./cmd"}},
+		{"交替模式保持位置参数顺序", true, []string{"a", "-o", "out", "b", "--race", "c"}, true, "out", []string{"a", "b", "c"}},
+		{"交替模式遇到双横杠停止", true, []string{"a", "--", "--race", "b"}, false, "", []string{"a", "--race", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cmd.NewCmd("build", "b", types.ContinueOnError)
+			c.SetInterspersed(tt.interspersed)
+			race := c.Bool("race", "r", "竞态检测", false)
+			output := c.String("output", "o", "输出文件", "")
+
+			if err := c.Parse(tt.args); err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+
+			if race.Get() != tt.race {
+				t.Errorf("race: expected %v, got %v", tt.race, race.Get())
+			}
+			if output.Get() != tt.output {
+				t.Errorf("output: expected %q, got %q", tt.output, output.Get())
+			}
+			if len(c.Args()) != len(tt.wantArgs) {
+				t.Fatalf("args: expected %v, got %v", tt.wantArgs, c.Args())
+			}
+			for i, a := range tt.wantArgs {
+				if c.Args()[i] != a {
+					t.Errorf("args[%d]: expected %q, got %q", i, a, c.Args()[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParser_InterspersedUnknownFlag(t *testing.T) {
+	c := cmd.NewCmd("build", "b", types.ContinueOnError)
+	c.SetInterspersed(true)
+	c.Bool("race", "r", "竞态检测", false)
+
+	err := c.Parse([]string{"./pkg", "--rac"})
+	if _, ok := err.(*types.UnknownFlagError); !ok {
+		t.Fatalf("expected UnknownFlagError, got %T: %v", err, err)
+	}
+}
+
+func TestParser_InterspersedSubCmd(t *testing.T) {
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.SetInterspersed(true)
+	verbose := root.Bool("verbose", "v", "详细输出", false)
+
+	sub := cmd.NewCmd("build", "", types.ContinueOnError)
+	race := sub.Bool("race", "r", "竞态检测", false)
+	if err := root.AddSubCmds(sub); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	if err := root.Parse([]string{"-v", "build", "./pkg", "--race"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !verbose.Get() {
+		t.Error("verbose should be set on root")
+	}
+	// 子命令未开启交替模式, --race 保留为位置参数
+	if race.Get() {
+		t.Error("race should not be parsed without interspersed mode on subcommand")
+	}
+	if len(sub.Args()) != 2 || sub.Args()[1] != "--race" {
+		t.Errorf("unexpected sub args: %v", sub.Args())
+	}
+}
+
+func TestParser_NegatableBool(t *testing.T) {
+	newCmd := func() (*cmd.Cmd, *flag.BoolFlag) {
+		c := cmd.NewCmd("build", "b", types.ContinueOnError)
+		cache := c.Bool("cache", "c", "启用缓存", true)
+		cache.SetNegatable(true)
+		return c, cache
+	}
+
+	c, cache := newCmd()
+	if err := c.Parse([]string{"--no-cache"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if cache.Get() || !cache.IsSet() {
+		t.Errorf("--no-cache: expected false and set, got %v (set=%v)", cache.Get(), cache.IsSet())
+	}
+
+	// 命令行取反优先于环境变量
+	t.Setenv("BUILD_CACHE", "true")
+	c, cache = newCmd()
+	cache.BindEnv("BUILD_CACHE")
+	if err := c.Parse([]string{"--no-cache"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if cache.Get() {
+		t.Error("--no-cache should override env binding")
+	}
+
+	c, _ = newCmd()
+	if err := c.Parse([]string{"--no-cache=true"}); err == nil {
+		t.Error("expected error for value on negated flag")
+	}
+
+	// 未开启取反的标志不接受 --no- 形式, 纠错建议包含取反形式
+	c, _ = newCmd()
+	c.Bool("color", "", "彩色输出", true)
+	err := c.Parse([]string{"--no-color"})
+	if _, ok := err.(*types.UnknownFlagError); !ok {
+		t.Fatalf("expected UnknownFlagError, got %T: %v", err, err)
+	}
+	err = c.Parse([]string{"--no-cach"})
+	if ufe, ok := err.(*types.UnknownFlagError); !ok || !slices.Contains(ufe.Suggestions, "--no-cache") {
+		t.Errorf("expected suggestion --no-cache, got %v", err)
+	}
+
+	c, _ = newCmd()
+	if !strings.Contains(c.Help(), "-c, --[no-]cache") {
+		t.Errorf("help should show negatable form:\n%s", c.Help())
+	}
+}
+
+func TestParser_AccumulateFlags(t *testing.T) {
+	t.Setenv("APP_TAGS", "env1,env2")
+
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	tags := root.StringSlice("tag", "t", "标签", []string{"default"})
+	tags.SetAccumulate(true)
+	tags.BindEnv("APP_TAGS")
+	if err := root.MarkPersistent("tag"); err != nil {
+		t.Fatalf("MarkPersistent error: %v", err)
+	}
+	labels := root.Map("label", "l", "标签", nil)
+	labels.SetAccumulate(true)
+
+	sub := cmd.NewCmd("run", "", types.ContinueOnError)
+	if err := root.AddSubCmds(sub); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	// 未出现在命令行时使用环境变量值
+	if err := root.Parse([]string{}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 2 || got[0] != "env1" {
+		t.Errorf("tags: expected env value, got %v", got)
+	}
+
+	// 命令行值替换环境变量值, 重复出现时追加
+	if err := root.Parse([]string{"--tag", "a", "-tb", "--label", "k1=v1", "-l", "k2=v2"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("tags: expected [a b], got %v", got)
+	}
+	if got := labels.Get(); len(got) != 2 || got["k1"] != "v1" || got["k2"] != "v2" {
+		t.Errorf("labels: expected {k1:v1 k2:v2}, got %v", got)
+	}
+
+	// 持久标志: 子命令中的第一次出现替换根命令加载的环境变量值
+	if err := root.Parse([]string{"run", "--tag", "x", "--tag", "y"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 2 || got[0] != "x" || got[1] != "y" {
+		t.Errorf("tags: expected [x y], got %v", got)
+	}
+
+	// 根命令和子命令中的出现一起累积
+	if err := root.Parse([]string{"--tag", "a", "run", "--tag", "b"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("tags: expected [a b], got %v", got)
+	}
+}
+
+func TestParser_AccumulateValidation(t *testing.T) {
+	newCmd := func() (*cmd.Cmd, *flag.StringSliceFlag, *flag.MapFlag) {
+		root := cmd.NewCmd("app", "", types.ContinueOnError)
+		tags := root.StringSlice("tag", "t", "标签", nil)
+		tags.SetAccumulate(true)
+		tags.SetValidator(validators.SliceMinLength[string](2))
+		if err := root.MarkPersistent("tag"); err != nil {
+			t.Fatalf("MarkPersistent error: %v", err)
+		}
+		labels := root.Map("label", "l", "标签", nil)
+		labels.SetAccumulate(true)
+		labels.SetValidator(validators.MapRequiredKeys[string]("a", "b"))
+		sub := cmd.NewCmd("run", "", types.ContinueOnError)
+		if err := root.AddSubCmds(sub); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		return root, tags, labels
+	}
+
+	// 验证器作用于合并后的值, 第一次出现时不验证
+	root, tags, labels := newCmd()
+	if err := root.Parse([]string{"--tag", "a", "--tag", "b", "--label", "a=1", "--label", "b=2"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 2 || got[1] != "b" {
+		t.Errorf("tags: expected [a b], got %v", got)
+	}
+	if got := labels.Get(); len(got) != 2 || got["b"] != "2" {
+		t.Errorf("labels: expected {a:1 b:2}, got %v", got)
+	}
+
+	// 持久标志在根命令和子命令中的出现合并后验证
+	root, tags, _ = newCmd()
+	if err := root.Parse([]string{"--tag", "a", "run", "--tag", "b"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := tags.Get(); len(got) != 2 {
+		t.Errorf("tags: expected [a b], got %v", got)
+	}
+
+	// 重新解析且未在命令行出现时, 不对默认值执行累积验证
+	root, tags, _ = newCmd()
+	tags.SetValidator(func(v []string) error {
+		if len(v) < 2 {
+			return fmt.Errorf("need at least 2 tags, got %v", v)
+		}
+		return nil
+	})
+	if err := root.Parse([]string{"--tag", "a", "--tag", "b"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if err := root.Parse([]string{}); err != nil {
+		t.Errorf("re-parse without tags should not validate the default, got %v", err)
+	}
+
+	// 合并后的值仍不满足时报告错误
+	root, _, _ = newCmd()
+	err := root.Parse([]string{"--tag", "a", "--label", "a=1", "--label", "b=2"})
+	var invalidErr *types.InvalidValueError
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("expected InvalidValueError, got %v", err)
+	}
+	if invalidErr.Source != types.SourceCLI || invalidErr.Location != "--tag" {
+		t.Errorf("unexpected error origin: %+v", invalidErr)
+	}
+
+	// 错误收集模式下一并报告
+	root, _, _ = newCmd()
+	root.SetCollectErrors(true)
+	err = root.Parse([]string{"--tag", "a", "--label", "a=1"})
+	var parseErrs *types.ParseErrors
+	if !errors.As(err, &parseErrs) || len(parseErrs.Errors) != 2 {
+		t.Fatalf("expected 2 collected errors, got %v", err)
+	}
+}
+
+func TestParser_CountFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantPos []string
+	}{
+		{"重复短标志", []string{"-v", "-v", "-v"}, 3, nil},
+		{"组合短标志", []string{"-vvv", "file"}, 3, []string{"file"}},
+		{"与其他标志组合", []string{"-vqv"}, 2, nil},
+		{"长标志", []string{"--verbose", "--verbose"}, 2, nil},
+		{"显式设置", []string{"--verbose=3"}, 3, nil},
+		{"显式设置后继续计数", []string{"-v=2", "-v"}, 3, nil},
+		{"不取走下一个参数", []string{"-v", "4"}, 1, []string{"4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cmd.NewCmd("test", "", types.ContinueOnError)
+			verbose := c.Count("verbose", "v", "详细级别", 0)
+			c.Bool("quiet", "q", "静默", false)
+
+			if err := c.Parse(tt.args); err != nil {
+				t.Fatalf("Parse error: %v", err)
+			}
+			if verbose.Get() != tt.want {
+				t.Errorf("verbose: expected %d, got %d", tt.want, verbose.Get())
+			}
+			if !slices.Equal(c.Args(), tt.wantPos) && len(c.Args())+len(tt.wantPos) > 0 {
+				t.Errorf("args: expected %v, got %v", tt.wantPos, c.Args())
+			}
+		})
+	}
+
+	// 非零默认值和根命令加载的环境变量值不作为计数起点
+	t.Setenv("APP_VERBOSE", "5")
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	level := root.Count("verbose", "v", "详细级别", 2)
+	level.BindEnv("APP_VERBOSE")
+	if err := root.MarkPersistent("verbose"); err != nil {
+		t.Fatalf("MarkPersistent error: %v", err)
+	}
+	if err := root.AddSubCmds(cmd.NewCmd("run", "", types.ContinueOnError)); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+	for _, args := range [][]string{{"-vvv"}, {"run", "-vvv"}, {"-v", "run", "-vv"}} {
+		if err := root.Parse(args); err != nil {
+			t.Fatalf("Parse(%v) error: %v", args, err)
+		}
+		if level.Get() != 3 {
+			t.Errorf("Parse(%v): expected 3, got %d", args, level.Get())
+		}
+	}
+
+	c := cmd.NewCmd("test", "", types.ContinueOnError)
+	verbose := c.Count("verbose", "v", "详细级别", 0)
+	verbose.SetValidator(validators.IntRange(0, 2))
+	if err := c.Parse([]string{"-vvv"}); err == nil {
+		t.Error("expected IntRange validator error")
+	}
+	if !strings.Contains(c.Help(), "-v, --verbose <count>") {
+		t.Errorf("help should render count flag:\n%s", c.Help())
+	}
+}
+
+func TestParser_ImplicitValue(t *testing.T) {
+	newCmd := func() (*cmd.Cmd, *flag.EnumFlag, *flag.StringFlag, *flag.IntFlag, *flag.DurationFlag) {
+		c := cmd.NewCmd("test", "", types.ContinueOnError)
+		color := c.Enum("color", "c", "彩色输出", "auto", []string{"auto", "always", "never"})
+		color.SetImplicitValue("always")
+		debug := c.String("debug", "d", "调试级别", "")
+		debug.SetImplicitValue("info")
+		jobs := c.Int("jobs", "j", "并发数", 1)
+		jobs.SetImplicitValue(4)
+		wait := c.Duration("wait", "w", "等待时间", 0)
+		wait.SetImplicitValue(30 * time.Second)
+		return c, color, debug, jobs, wait
+	}
+
+	c, color, debug, jobs, wait := newCmd()
+	if err := c.Parse([]string{"--color", "--debug", "-j", "-w", "file"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if color.Get() != "always" || debug.Get() != "info" || jobs.Get() != 4 || wait.Get() != 30*time.Second {
+		t.Errorf("implicit values not applied: color=%q debug=%q jobs=%d wait=%v", color.Get(), debug.Get(), jobs.Get(), wait.Get())
+	}
+	if !slices.Equal(c.Args(), []string{"file"}) {
+		t.Errorf("args: expected [file], got %v", c.Args())
+	}
+
+	c, color, debug, jobs, _ = newCmd()
+	if err := c.Parse([]string{"--color=never", "-dtrace", "-j=8", "2"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if color.Get() != "never" || debug.Get() != "trace" || jobs.Get() != 8 {
+		t.Errorf("explicit values not applied: color=%q debug=%q jobs=%d", color.Get(), debug.Get(), jobs.Get())
+	}
+	if !slices.Equal(c.Args(), []string{"2"}) {
+		t.Errorf("next token must not be consumed, got args %v", c.Args())
+	}
+
+	c, _, _, _, _ = newCmd()
+	if err := c.Parse([]string{"--color=bad"}); err == nil {
+		t.Error("expected error for value outside enum")
+	}
+	if !strings.Contains(c.Help(), "-c, --color[=<enum>]") {
+		t.Errorf("help should show optional value form:\n%s", c.Help())
+	}
+}
+
+func TestParser_Abbreviation(t *testing.T) {
+	newTree := func(abbrev bool) (*cmd.Cmd, *flag.BoolFlag, *flag.StringFlag, *bool) {
+		root := cmd.NewCmd("mytool", "", types.ContinueOnError)
+		root.SetAllowAbbrev(abbrev)
+		verbose := root.Bool("verbose", "v", "详细输出", false)
+		root.Bool("verify", "", "校验", false)
+
+		server := cmd.NewCmd("server", "", types.ContinueOnError)
+		addr := server.String("address", "a", "监听地址", "")
+		ran := new(bool)
+		start := cmd.NewCmd("start", "", types.ContinueOnError)
+		start.SetRun(func(types.Command) error { *ran = true; return nil })
+		status := cmd.NewCmd("status", "", types.ContinueOnError)
+		status.SetRun(func(types.Command) error { return nil })
+		if err := server.AddSubCmds(start, status); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		if err := root.AddSubCmds(server); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		return root, verbose, addr, ran
+	}
+
+	root, verbose, addr, ran := newTree(true)
+	if err := root.ParseAndRoute([]string{"--verb", "ser", "--addr=:80", "star"}); err != nil {
+		t.Fatalf("ParseAndRoute error: %v", err)
+	}
+	if !verbose.Get() || addr.Get() != ":80" || !*ran {
+		t.Errorf("abbreviations not resolved: verbose=%v addr=%q ran=%v", verbose.Get(), addr.Get(), *ran)
+	}
+
+	root, _, _, _ = newTree(true)
+	err := root.Parse([]string{"--ver"})
+	var flagErr *types.AmbiguousFlagError
+	if !errors.As(err, &flagErr) || !slices.Equal(flagErr.Candidates, []string{"--verbose", "--verify"}) {
+		t.Errorf("expected AmbiguousFlagError with candidates, got %v", err)
+	}
+
+	root, _, _, _ = newTree(true)
+	err = root.Parse([]string{"server", "st"})
+	var cmdErr *types.AmbiguousSubcommandError
+	if !errors.As(err, &cmdErr) || !slices.Equal(cmdErr.Candidates, []string{"start", "status"}) {
+		t.Errorf("expected AmbiguousSubcommandError with candidates, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "\tstart\n") {
+		t.Errorf("error should list candidates:\n%v", err)
+	}
+
+	// 默认关闭缩写
+	root, _, _, _ = newTree(false)
+	if _, ok := root.Parse([]string{"--verb"}).(*types.UnknownFlagError); !ok {
+		t.Error("expected UnknownFlagError when abbreviation is disabled")
+	}
+}
+
+func TestParser_ResponseFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile error: %v", err)
+		}
+		return path
+	}
+
+	writeFile("nested.txt", "--tag 'b c' # 注释\n")
+	main := writeFile("args.txt", "# 公共参数\n--name \"hello world\" \\\n  --count 3\n@nested.txt\n--tag \"say \\\"hi\\\"\"\n")
+
+	newTree := func(enable bool) (*cmd.Cmd, *flag.StringFlag, *flag.IntFlag, *flag.StringSliceFlag, *cmd.Cmd) {
+		root := cmd.NewCmd("mytool", "", types.ContinueOnError)
+		root.SetResponseFiles(enable)
+		name := root.String("name", "n", "名称", "")
+		count := root.Int("count", "c", "次数", 0)
+		tags := root.StringSlice("tag", "t", "标签", nil)
+		tags.SetAccumulate(true)
+		root.SetRun(func(types.Command) error { return nil })
+
+		raw := cmd.NewCmd("raw", "", types.ContinueOnError)
+		raw.SetDisableFlagParsing(true)
+		raw.SetRun(func(types.Command) error { return nil })
+		if err := root.AddSubCmds(raw); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		return root, name, count, tags, raw
+	}
+
+	root, name, count, tags, _ := newTree(true)
+	if err := root.Parse([]string{"@" + main, "@@literal"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if name.Get() != "hello world" || count.Get() != 3 {
+		t.Errorf("name=%q count=%d", name.Get(), count.Get())
+	}
+	if want := []string{"b c", `say "hi"`}; !slices.Equal(tags.Get(), want) {
+		t.Errorf("tags = %q, want %q", tags.Get(), want)
+	}
+	if want := []string{"@literal"}; !slices.Equal(root.Args(), want) {
+		t.Errorf("Args() = %q, want %q", root.Args(), want)
+	}
+
+	// 禁用标志解析的子命令原样接收参数, -- 之后不展开
+	root, _, _, _, raw := newTree(true)
+	if err := root.ParseAndRoute([]string{"raw", "@" + main}); err != nil {
+		t.Fatalf("ParseAndRoute error: %v", err)
+	}
+	if want := []string{"@" + main}; !slices.Equal(raw.Args(), want) {
+		t.Errorf("raw Args() = %q, want %q", raw.Args(), want)
+	}
+	if err := root.Parse([]string{"--", "@" + main}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if want := []string{"@" + main}; !slices.Equal(root.Args(), want) {
+		t.Errorf("Args() after -- = %q, want %q", root.Args(), want)
+	}
+
+	// 标志值不会被当作子命令名或位置参数
+	root, _, count, _, _ = newTree(true)
+	if err := root.Parse([]string{"--name", "raw", "@" + main}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if count.Get() != 3 {
+		t.Errorf("response file after a flag value matching a subcommand should be expanded, count=%d", count.Get())
+	}
+	root, _, _, _, raw = newTree(true)
+	if err := root.ParseAndRoute([]string{"--count", "5", "raw", "@" + main}); err != nil {
+		t.Fatalf("ParseAndRoute error: %v", err)
+	}
+	if want := []string{"@" + main}; !slices.Equal(raw.Args(), want) {
+		t.Errorf("raw Args() after a flag value = %q, want %q", raw.Args(), want)
+	}
+
+	// 默认关闭
+	root, _, _, _, _ = newTree(false)
+	if err := root.Parse([]string{"@" + main}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if want := []string{"@" + main}; !slices.Equal(root.Args(), want) {
+		t.Errorf("Args() when disabled = %q, want %q", root.Args(), want)
+	}
+
+	// 循环引用、缺失文件和未闭合的引号
+	loop := writeFile("loop.txt", "@loop2.txt\n")
+	writeFile("loop2.txt", "@loop.txt\n")
+	bad := writeFile("bad.txt", "--name 'oops\n")
+	for _, arg := range []string{"@" + loop, "@" + filepath.Join(dir, "missing.txt"), "@" + bad} {
+		root, _, _, _, _ = newTree(true)
+		if err := root.Parse([]string{arg}); err == nil {
+			t.Errorf("Parse(%q) expected error", arg)
+		}
+	}
+}
+
+func TestParser_ErrorPolicyPhases(t *testing.T) {
+	t.Setenv("APP_PORT", "abc")
+	dir := t.TempDir()
+	badQuote := filepath.Join(dir, "bad.rsp")
+	if err := os.WriteFile(badQuote, []byte(`--name "unterminated`), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		setup func(c *cmd.Cmd)
+	}{
+		{"缺少响应文件", []string{"@" + filepath.Join(dir, "missing.rsp")}, nil},
+		{"响应文件引号未闭合", []string{"@" + badQuote}, nil},
+		{"环境变量值无效", nil, func(c *cmd.Cmd) { c.Int("port", "p", "端口", 0).BindEnv("APP_PORT") }},
+		{"缺少位置参数", nil, func(c *cmd.Cmd) { c.StringArg("dst", "目标路径", true, "") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr strings.Builder
+			c := cmd.NewCmd("app", "", types.PanicOnError)
+			c.SetErr(&stderr)
+			c.SetOut(&strings.Builder{})
+			c.SetResponseFiles(true)
+			c.String("name", "n", "名称", "")
+			if tt.setup != nil {
+				tt.setup(c)
+			}
+
+			panicked := func() (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+				_ = c.Parse(tt.args)
+				return false
+			}()
+			if !panicked {
+				t.Error("expected PanicOnError to panic")
+			}
+			if stderr.Len() == 0 {
+				t.Error("expected error to be printed")
+			}
+			if !c.IsParsed() {
+				t.Error("command should be marked as parsed")
+			}
+		})
+	}
+}
+
+func TestParser_ConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile error: %v", err)
+		}
+		return path
+	}
+
+	type tree struct {
+		root, server     *cmd.Cmd
+		name, host, addr *flag.StringFlag
+		count            *flag.IntFlag
+		tags             *flag.StringSliceFlag
+		labels           *flag.MapFlag
+		timeout          *flag.DurationFlag
+	}
+	newTree := func(path string) *tree {
+		tr := &tree{}
+		tr.root = cmd.NewCmd("mytool", "", types.ContinueOnError)
+		tr.root.SetConfigFile(path)
+		tr.name = tr.root.String("name", "n", "名称", "default")
+		tr.host = tr.root.String("host", "", "主机", "localhost")
+		tr.host.BindEnv("MYTOOL_CFG_HOST")
+		tr.count = tr.root.Int("count", "c", "次数", 1)
+		tr.tags = tr.root.StringSlice("tags", "", "标签", nil)
+		tr.labels = tr.root.Map("labels", "", "标签映射", nil)
+
+		tr.server = cmd.NewCmd("server", "s", types.ContinueOnError)
+		tr.addr = tr.server.String("address", "a", "监听地址", ":80")
+		tr.timeout = tr.server.Duration("timeout", "", "超时", time.Second)
+		tr.server.SetRun(func(types.Command) error { return nil })
+		if err := tr.root.AddSubCmds(tr.server); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		return tr
+	}
+
+	jsonPath := writeFile("app.json", `{
+		"name": "from-config",
+		"host": "config-host",
+		"count": 5,
+		"tags": ["a,b", "c"],
+		"labels": {"env": "prod", "team": "core"},
+		"server": {"address": ":8080", "timeout": "3s"}
+	}`)
+	tomlPath := writeFile("app.toml", `
+name = "from-config"
+host = config-host
+count = 5
+tags = ["a,b", "c"]
+
+[server]
+address = ":8080"
+timeout = 3s
+`)
+
+	for _, path := range []string{jsonPath, tomlPath} {
+		t.Setenv("MYTOOL_CFG_HOST", "env-host")
+
+		// 优先级: 命令行参数 > 环境变量 > 配置文件 > 默认值
+		tr := newTree(path)
+		if err := tr.root.ParseAndRoute([]string{"--count", "7", "server", "-a", ":9090"}); err != nil {
+			t.Fatalf("%s: ParseAndRoute error: %v", path, err)
+		}
+		if tr.name.Get() != "from-config" || tr.host.Get() != "env-host" || tr.count.Get() != 7 {
+			t.Errorf("%s: name=%q host=%q count=%d", path, tr.name.Get(), tr.host.Get(), tr.count.Get())
+		}
+		if want := []string{"a,b", "c"}; !slices.Equal(tr.tags.Get(), want) {
+			t.Errorf("%s: tags = %q, want %q", path, tr.tags.Get(), want)
+		}
+		if tr.addr.Get() != ":9090" || tr.timeout.Get() != 3*time.Second {
+			t.Errorf("%s: address=%q timeout=%v", path, tr.addr.Get(), tr.timeout.Get())
+		}
+	}
+
+	tr := newTree(jsonPath)
+	if err := tr.root.Parse(nil); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if tr.labels.Get()["env"] != "prod" || tr.labels.Get()["team"] != "core" {
+		t.Errorf("labels = %v", tr.labels.Get())
+	}
+
+	// 配置文件不存在时使用默认值
+	tr = newTree(filepath.Join(dir, "missing.json"))
+	if err := tr.root.Parse(nil); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if tr.name.Get() != "default" {
+		t.Errorf("name = %q, want default", tr.name.Get())
+	}
+
+	// 子命令分区可以设置根命令的持久标志, 覆盖根分区中的值
+	tr = newTree(writeFile("persistent.toml", "name = root-name\n\n[server]\nname = server-name\n"))
+	if err := tr.root.MarkPersistent("name"); err != nil {
+		t.Fatalf("MarkPersistent error: %v", err)
+	}
+	if err := tr.root.Parse([]string{"server"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if tr.name.Get() != "server-name" {
+		t.Errorf("persistent flag in [server] section: name = %q, want server-name", tr.name.Get())
+	}
+	if err := tr.root.Parse([]string{"--name", "cli", "server"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if tr.name.Get() != "cli" {
+		t.Errorf("command line should win over [server] section: name = %q", tr.name.Get())
+	}
+
+	// 未知键和无效值返回错误
+	for _, content := range []string{`{"nmae": "x"}`, `{"count": "many"}`, `{"server": {"port": 1}}`} {
+		tr = newTree(writeFile("bad.json", content))
+		if err := tr.root.Parse([]string{"server"}); err == nil {
+			t.Errorf("config %s: expected error", content)
+		}
+	}
+}
+
+func TestParser_ValueOrigins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.toml")
+	if err := os.WriteFile(path, []byte("host = config-host\nport = 9000\n\n[server]\nworkers = 4\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	t.Setenv("APP_PORT", "7000")
+
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.SetConfigFile(path)
+	root.SetEnvPrefix("APP")
+	name := root.String("name", "n", "名称", "anon")
+	host := root.String("host", "", "主机", "localhost")
+	port := root.Int("port", "p", "端口", 80)
+	port.AutoBindEnv()
+	debug := root.Bool("debug", "d", "调试", false)
+	if err := root.MarkPersistent("debug"); err != nil {
+		t.Fatalf("MarkPersistent error: %v", err)
+	}
+
+	server := cmd.NewCmd("server", "", types.ContinueOnError)
+	workers := server.Int("workers", "w", "工作线程", 1)
+	server.SetRun(func(types.Command) error { return nil })
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	if err := root.ParseAndRoute([]string{"-n", "bob", "server", "--debug"}); err != nil {
+		t.Fatalf("ParseAndRoute error: %v", err)
+	}
+
+	tests := []struct {
+		flag types.Flag
+		want types.ValueOrigin
+	}{
+		{name, types.ValueOrigin{Source: types.SourceCLI, Raw: "bob", Location: "-n"}},
+		{host, types.ValueOrigin{Source: types.SourceConfig, Raw: "config-host", Location: path + ":host"}},
+		{port, types.ValueOrigin{Source: types.SourceEnv, Raw: "7000", Location: "APP_PORT"}},
+		{debug, types.ValueOrigin{Source: types.SourceCLI, Raw: "true", Location: "--debug"}},
+		{workers, types.ValueOrigin{Source: types.SourceConfig, Raw: "4", Location: path + ":server.workers"}},
+	}
+	for _, tt := range tests {
+		if got := tt.flag.Origin(); got != tt.want {
+			t.Errorf("%s origin = %+v, want %+v", tt.flag.Name(), got, tt.want)
+		}
+	}
+
+	origins := server.FlagOrigins()
+	var sawWorkers, sawDebug bool
+	for _, o := range origins {
+		switch o.Flag.Name() {
+		case "workers":
+			sawWorkers = !o.Inherited && o.Origin.Source == types.SourceConfig
+		case "debug":
+			sawDebug = o.Inherited && o.Origin.Source == types.SourceCLI
+		}
+	}
+	if !sawWorkers || !sawDebug {
+		t.Errorf("FlagOrigins() = %+v", origins)
+	}
+
+	// 重新解析后来源随之更新
+	if err := root.Parse(nil); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := name.Origin(); got.Source != types.SourceDefault || got.Raw != "anon" {
+		t.Errorf("name origin after reparse = %+v", got)
+	}
+}
+
+func TestParser_CollectErrors(t *testing.T) {
+	newCmd := func(collect bool) *cmd.Cmd {
+		c := cmd.NewCmd("app", "", types.ContinueOnError)
+		c.SetCollectErrors(collect)
+		c.SetEnvPrefix("COLLECT")
+		c.Int("port", "p", "端口", 0)
+		c.Duration("timeout", "t", "超时", 0)
+		c.Bool("json", "", "JSON 输出", false)
+		c.Bool("yaml", "", "YAML 输出", false)
+		c.String("name", "n", "名称", "")
+		level := c.Int("level", "", "级别", 0)
+		level.AutoBindEnv()
+		if err := c.AddMutexGroup("format", []string{"json", "yaml"}, true); err != nil {
+			t.Fatalf("AddMutexGroup error: %v", err)
+		}
+		if err := c.AddRequiredGroup("base", []string{"name"}, false); err != nil {
+			t.Fatalf("AddRequiredGroup error: %v", err)
+		}
+		return c
+	}
+	t.Setenv("COLLECT_LEVEL", "high")
+	args := []string{"--port", "abc", "--bogus", "-t", "soon", "--json", "--yaml"}
+
+	// 默认遇到第一个错误即返回
+	err := newCmd(false).Parse(args)
+	var multi *types.ParseErrors
+	if err == nil || errors.As(err, &multi) {
+		t.Fatalf("default mode error = %v, want single error", err)
+	}
+
+	err = newCmd(true).Parse(args)
+	if !errors.As(err, &multi) {
+		t.Fatalf("collect mode error = %v, want *types.ParseErrors", err)
+	}
+	// 未知标志, 两个无效值, 无效的环境变量, 互斥组和必需组
+	if len(multi.Errors) != 6 {
+		t.Errorf("collected %d errors, want 6:\n%v", len(multi.Errors), err)
+	}
+	var unknown *types.UnknownFlagError
+	if !errors.As(err, &unknown) || unknown.Input != "--bogus" {
+		t.Errorf("errors.As UnknownFlagError failed: %v", err)
+	}
+	for _, want := range []string{"--port", "-t", "COLLECT_LEVEL", "group 'format'", "group 'base'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("aggregate error missing %q:\n%v", want, err)
+		}
+	}
+
+	// 没有错误时正常解析
+	if err := newCmd(true).Parse([]string{"-n", "x", "--level", "1"}); err != nil {
+		t.Errorf("Parse error: %v", err)
+	}
+}
+
+func TestParser_TypedErrors(t *testing.T) {
+	newCmd := func() (*cmd.Cmd, *cmd.Cmd) {
+		root := cmd.NewCmd("app", "", types.ContinueOnError)
+		sub := cmd.NewCmd("deploy", "", types.ContinueOnError)
+		sub.Enum("mode", "m", "模式", "fast", []string{"fast", "safe"})
+		sub.Bool("json", "", "JSON 输出", false)
+		sub.Bool("yaml", "", "YAML 输出", false)
+		sub.String("user", "", "用户", "")
+		sub.String("token", "", "令牌", "")
+		sub.EnumArg("env", "环境", false, "dev", []string{"dev", "prod"})
+		if err := sub.AddMutexGroup("format", []string{"json", "yaml"}, true); err != nil {
+			t.Fatalf("AddMutexGroup error: %v", err)
+		}
+		if err := sub.AddFlagDependency("auth", "user", []string{"token"}, types.DepRequired); err != nil {
+			t.Fatalf("AddFlagDependency error: %v", err)
+		}
+		sub.SetRun(func(types.Command) error { return nil })
+		if err := root.AddSubCmds(sub); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		return root, sub
+	}
+
+	t.Run("无效值", func(t *testing.T) {
+		root, _ := newCmd()
+		err := root.Parse([]string{"deploy", "-m", "slow"})
+		var e *types.InvalidValueError
+		if !errors.As(err, &e) {
+			t.Fatalf("error = %v, want *types.InvalidValueError", err)
+		}
+		if e.Path != "app deploy" || e.Flag != "mode" || e.Value != "slow" || e.Location != "-m" ||
+			e.Source != types.SourceCLI || !slices.Equal(slices.Sorted(slices.Values(e.Allowed)), []string{"fast", "safe"}) || e.Err == nil {
+			t.Errorf("InvalidValueError = %+v", e)
+		}
+	})
+
+	t.Run("缺少值", func(t *testing.T) {
+		root, _ := newCmd()
+		var e *types.MissingValueError
+		if err := root.Parse([]string{"deploy", "--user"}); !errors.As(err, &e) || e.Flag != "--user" {
+			t.Errorf("error = %v, want *types.MissingValueError", err)
+		}
+	})
+
+	t.Run("互斥组", func(t *testing.T) {
+		root, _ := newCmd()
+		var e *types.MutexGroupError
+		err := root.Parse([]string{"deploy", "--json", "--yaml"})
+		if !errors.As(err, &e) || e.Group != "format" || len(e.Flags) != 2 || e.NoneSet {
+			t.Errorf("error = %v, want *types.MutexGroupError", err)
+		}
+	})
+
+	t.Run("依赖关系", func(t *testing.T) {
+		root, _ := newCmd()
+		var e *types.DependencyError
+		err := root.Parse([]string{"deploy", "--user", "bob"})
+		if !errors.As(err, &e) || e.Dependency != "auth" || e.Type != types.DepRequired || len(e.Flags) != 1 {
+			t.Errorf("error = %v, want *types.DependencyError", err)
+		}
+	})
+
+	t.Run("位置参数", func(t *testing.T) {
+		root, _ := newCmd()
+		var invalid *types.InvalidArgumentError
+		err := root.Parse([]string{"deploy", "stage"})
+		if !errors.As(err, &invalid) || invalid.Arg != "env" || !slices.Equal(slices.Sorted(slices.Values(invalid.Allowed)), []string{"dev", "prod"}) {
+			t.Errorf("error = %v, want *types.InvalidArgumentError", err)
+		}
+
+		root, _ = newCmd()
+		var tooMany *types.TooManyArgumentsError
+		err = root.Parse([]string{"deploy", "dev", "extra"})
+		if !errors.As(err, &tooMany) || tooMany.Max != 1 || tooMany.Got != 2 {
+			t.Errorf("error = %v, want *types.TooManyArgumentsError", err)
+		}
+	})
+
+	t.Run("未知标志", func(t *testing.T) {
+		root, _ := newCmd()
+		var e *types.UnknownFlagError
+		if err := root.Parse([]string{"deploy", "--mdoe", "fast"}); !errors.As(err, &e) || e.Path != "app deploy" {
+			t.Errorf("error = %v, want *types.UnknownFlagError", err)
+		}
+	})
+
+	t.Run("没有运行函数", func(t *testing.T) {
+		root, _ := newCmd()
+		var e *types.NoRunFuncError
+		if err := root.ParseAndRoute(nil); !errors.As(err, &e) || e.Path != "app" {
+			t.Errorf("error = %v, want *types.NoRunFuncError", err)
+		}
+	})
+}
+
+func TestParser_LifecycleHooks(t *testing.T) {
+	var calls []string
+	hook := func(name string, err error) func(types.Command) error {
+		return func(c types.Command) error {
+			calls = append(calls, name+":"+c.Name())
+			return err
+		}
+	}
+
+	newTree := func(preErr, runErr error) *cmd.Cmd {
+		calls = nil
+		root := cmd.NewCmd("app", "", types.ContinueOnError)
+		root.SetPersistentPreRun(hook("root.ppre", nil))
+		root.SetPersistentPostRun(hook("root.ppost", nil))
+		root.SetPreRun(hook("root.pre", nil))
+
+		server := cmd.NewCmd("server", "", types.ContinueOnError)
+		server.SetPersistentPreRun(hook("server.ppre", preErr))
+		server.SetPersistentPostRun(hook("server.ppost", nil))
+
+		start := cmd.NewCmd("start", "", types.ContinueOnError)
+		start.SetPreRun(hook("start.pre", nil))
+		start.SetRun(hook("start.run", runErr))
+		start.SetPostRun(hook("start.post", nil))
+
+		if err := server.AddSubCmds(start); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		if err := root.AddSubCmds(server); err != nil {
+			t.Fatalf("AddSubCmds error: %v", err)
+		}
+		return root
+	}
+
+	// 正常执行顺序, 所有钩子接收实际执行的命令
+	if err := newTree(nil, nil).ParseAndRoute([]string{"server", "start"}); err != nil {
+		t.Fatalf("ParseAndRoute error: %v", err)
+	}
+	want := []string{"root.ppre:start", "server.ppre:start", "start.pre:start", "start.run:start",
+		"start.post:start", "server.ppost:start", "root.ppost:start"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// 前置钩子失败时中止执行
+	preErr := errors.New("auth failed")
+	if err := newTree(preErr, nil).ParseAndRoute([]string{"server", "start"}); !errors.Is(err, preErr) {
+		t.Errorf("error = %v, want %v", err, preErr)
+	}
+	if want := []string{"root.ppre:start", "server.ppre:start"}; !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	// 运行函数失败时仍执行后置钩子
+	runErr := errors.New("run failed")
+	if err := newTree(nil, runErr).ParseAndRoute([]string{"server", "start"}); err != runErr {
+		t.Errorf("error = %v, want %v", err, runErr)
+	}
+	if len(calls) != 7 || calls[len(calls)-1] != "root.ppost:start" {
+		t.Errorf("post hooks not run after failure: %v", calls)
+	}
+
+	// Parse 不执行钩子
+	if err := newTree(nil, nil).Parse([]string{"server", "start"}); err != nil || len(calls) != 0 {
+		t.Errorf("Parse error = %v, calls = %v", err, calls)
+	}
+}
+
+func TestParser_Middleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) types.Middleware {
+		return func(next types.RunFunc) types.RunFunc {
+			return func(c types.Command) error {
+				calls = append(calls, name+">")
+				err := next(c)
+				calls = append(calls, "<"+name)
+				return err
+			}
+		}
+	}
+
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.Use(trace("root1"), trace("root2"))
+	root.SetPreRun(func(c types.Command) error { return errors.New("root pre-run should not run") })
+
+	server := cmd.NewCmd("server", "", types.ContinueOnError)
+	port := server.Int("port", "p", "port", 0)
+	server.Use(trace("server"))
+	server.SetPreRun(func(c types.Command) error {
+		calls = append(calls, "pre")
+		return nil
+	})
+	server.SetRun(func(c types.Command) error {
+		calls = append(calls, "run")
+		return nil
+	})
+	server.SetPostRun(func(c types.Command) error {
+		calls = append(calls, "post")
+		return nil
+	})
+	if err := root.AddSubCmds(server); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	// 根命令的中间件在外层, 钩子在中间件链之外
+	var gotPath string
+	var gotPort string
+	server.Use(func(next types.RunFunc) types.RunFunc {
+		return func(c types.Command) error {
+			gotPath = c.Path()
+			if f, ok := c.GetFlag("port"); ok {
+				gotPort = f.GetStr()
+			}
+			return next(c)
+		}
+	})
+	if err := root.ParseAndRoute([]string{"server", "--port", "8080"}); err != nil {
+		t.Fatalf("ParseAndRoute error: %v", err)
+	}
+	want := []string{"pre", "root1>", "root2>", "server>", "run", "<server", "<root2", "<root1", "post"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if gotPath != "app server" || gotPort != "8080" || port.Get() != 8080 {
+		t.Errorf("middleware saw path %q port %q", gotPath, gotPort)
+	}
+
+	// 中间件可以拦截运行函数并把 panic 转换为错误
+	gate := errors.New("feature disabled")
+	app := cmd.NewCmd("app", "", types.ContinueOnError)
+	app.Use(func(next types.RunFunc) types.RunFunc {
+		return func(c types.Command) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("recovered: %v", r)
+				}
+			}()
+			return next(c)
+		}
+	})
+	app.SetRun(func(c types.Command) error { panic("boom") })
+	if err := app.ParseAndRoute(nil); err == nil || err.Error() != "recovered: boom" {
+		t.Errorf("error = %v, want recovered panic", err)
+	}
+
+	ran := false
+	gated := cmd.NewCmd("app", "", types.ContinueOnError)
+	gated.Use(func(next types.RunFunc) types.RunFunc {
+		return func(c types.Command) error { return gate }
+	})
+	gated.SetRun(func(c types.Command) error {
+		ran = true
+		return nil
+	})
+	if err := gated.ParseAndRoute(nil); err != gate || ran {
+		t.Errorf("error = %v, ran = %v, want gated", err, ran)
+	}
+}
+
+func TestParser_ParseAndRouteContext(t *testing.T) {
+	type ctxKey struct{}
+
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	sub := cmd.NewCmd("sync", "", types.ContinueOnError)
+	var got any
+	sub.SetPersistentPreRun(func(c types.Command) error {
+		got = c.Context().Value(ctxKey{})
+		return nil
+	})
+	sub.SetRun(func(c types.Command) error {
+		return c.Context().Err()
+	})
+	if err := root.AddSubCmds(sub); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	// 子命令继承根命令的上下文
+	ctx := context.WithValue(context.Background(), ctxKey{}, "v")
+	if err := root.ParseAndRouteContext(ctx, []string{"sync"}); err != nil {
+		t.Fatalf("ParseAndRouteContext error: %v", err)
+	}
+	if got != "v" {
+		t.Errorf("context value = %v, want v", got)
+	}
+
+	// 执行前已取消时返回取消原因, 不执行钩子和运行函数
+	got = nil
+	cause := &types.SignalError{Signal: os.Interrupt}
+	canceled, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+	err := root.ParseAndRouteContext(canceled, []string{"sync"})
+	var sigErr *types.SignalError
+	if !errors.As(err, &sigErr) || got != nil {
+		t.Errorf("error = %v, hook value = %v, want SignalError and no hook call", err, got)
+	}
+	if code, _ := utils.ContextExitCode(canceled); code != sigErr.ExitCode() {
+		t.Errorf("exit code = %d, want %d", code, sigErr.ExitCode())
+	}
+}
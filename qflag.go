@@ -1,6 +1,8 @@
 package qflag
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 )
@@ -35,7 +37,7 @@ func init() {
 // 注意事项:
 //   - 如果需要确保只解析一次, 请使用 ParseOnce
 func Parse() error {
-	return Root.Parse(os.Args[1:])
+	return handleBuiltinResult(Root.Parse(os.Args[1:]))
 }
 
 // ParseOnce 解析命令行参数（只解析一次）
@@ -53,7 +55,7 @@ func Parse() error {
 //   - 建议在普通场景使用此方法, 避免误用
 //   - 如果需要重复解析, 请使用 Parse
 func ParseOnce() error {
-	return Root.ParseOnce(os.Args[1:])
+	return handleBuiltinResult(Root.ParseOnce(os.Args[1:]))
 }
 
 // ParseOnly 仅解析当前命令, 不递归解析子命令
@@ -69,7 +71,7 @@ func ParseOnce() error {
 // 注意事项:
 //   - 如果需要确保只解析一次, 请使用 ParseOnlyOnce
 func ParseOnly() error {
-	return Root.ParseOnly(os.Args[1:])
+	return handleBuiltinResult(Root.ParseOnly(os.Args[1:]))
 }
 
 // ParseOnlyOnce 仅解析当前命令, 不递归解析子命令（只解析一次）
@@ -87,7 +89,7 @@ func ParseOnly() error {
 //   - 建议在普通场景使用此方法, 避免误用
 //   - 如果需要重复解析, 请使用 ParseOnly
 func ParseOnlyOnce() error {
-	return Root.ParseOnlyOnce(os.Args[1:])
+	return handleBuiltinResult(Root.ParseOnlyOnce(os.Args[1:]))
 }
 
 // ParseAndRoute 解析并路由执行命令
@@ -103,7 +105,62 @@ func ParseOnlyOnce() error {
 // 注意事项:
 //   - 如果需要确保只解析一次, 请使用 ParseAndRouteOnce
 func ParseAndRoute() error {
-	return Root.ParseAndRoute(os.Args[1:])
+	return handleBuiltinResult(Root.ParseAndRoute(os.Args[1:]))
+}
+
+// ParseAndRouteContext 使用上下文解析并路由执行命令
+//
+// 参数:
+//   - ctx: 执行上下文, 运行函数和钩子可以通过 cmd.Context() 读取
+//
+// 返回值:
+//   - error: 解析或执行失败时返回错误
+//
+// 功能说明:
+//   - 使用全局根命令解析命令行参数
+//   - 完整的解析和执行流程, 上下文在执行前已取消时返回取消原因
+//
+// 示例:
+//
+//	ctx, stop := qflag.SignalContext(context.Background())
+//	defer stop()
+//	if err := qflag.ParseAndRouteContext(ctx); err != nil {
+//	    if code, ok := qflag.ContextExitCode(ctx); ok {
+//	        os.Exit(code)
+//	    }
+//	}
+func ParseAndRouteContext(ctx context.Context) error {
+	return handleBuiltinResult(Root.ParseAndRouteContext(ctx, os.Args[1:]))
+}
+
+// Execute 使用全局根命令解析并路由执行命令, 失败时输出错误并按退出码退出程序
+//
+// 功能说明:
+//   - 成功或用户请求了帮助、版本、补全时正常返回
+//   - 失败时按根命令的语言设置输出错误信息 (开启中文时, 实现 LocalizedError 的错误输出中文信息),
+//     再按错误类型映射的退出码退出:
+//     调用方式错误为 2, 值验证错误为 SetValidationExitCode 设置的值 (默认 2),
+//     实现 ExitCoder 的错误使用自身的退出码, 其他错误为 1
+//
+// 示例:
+//
+//	func main() {
+//	    qflag.Root.SetValidationExitCode(65)
+//	    qflag.Execute()
+//	}
+func Execute() {
+	Root.Execute(os.Args[1:])
+}
+
+// ExecuteContext 使用上下文执行全局根命令, 失败时输出错误并按退出码退出程序
+//
+// 参数:
+//   - ctx: 执行上下文, 配合 SignalContext 使用时收到信号以 128 + 信号编号退出
+//
+// 功能说明:
+//   - 与 Execute 相同, 运行函数和钩子可以通过 cmd.Context() 读取上下文
+func ExecuteContext(ctx context.Context) {
+	Root.ExecuteContext(ctx, os.Args[1:])
 }
 
 // ParseAndRouteOnce 解析并路由执行命令（只解析一次）
@@ -121,7 +178,35 @@ func ParseAndRoute() error {
 //   - 建议在普通场景使用此方法, 避免误用
 //   - 如果需要重复解析, 请使用 ParseAndRoute
 func ParseAndRouteOnce() error {
-	return Root.ParseAndRouteOnce(os.Args[1:])
+	return handleBuiltinResult(Root.ParseAndRouteOnce(os.Args[1:]))
+}
+
+// handleBuiltinResult 按全局根命令的错误处理策略处理内置标志的结果
+//
+// 参数:
+//   - err: 解析或执行返回的错误
+//
+// 返回值:
+//   - error: 原样返回错误
+//
+// 功能说明:
+//   - 错误为 ErrHelp、ErrVersion 或 ErrCompletion 时表示内置标志已处理
+//   - ExitOnError 策略下以状态码 0 退出程序
+//   - PanicOnError 策略下触发 panic
+//   - ContinueOnError 策略下返回哨兵错误, 由调用方处理
+func handleBuiltinResult(err error) error {
+	if !errors.Is(err, ErrHelp) && !errors.Is(err, ErrVersion) && !errors.Is(err, ErrCompletion) {
+		return err
+	}
+
+	switch Root.ErrorHandling() {
+	case ExitOnError:
+		os.Exit(0)
+	case PanicOnError:
+		panic(err)
+	}
+
+	return err
 }
 
 // AddSubCmds 添加子命令到全局根命令
@@ -1,8 +1,11 @@
 package qflag
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -519,54 +522,34 @@ J__L  J__L J\______/F J__L J__L J\______/F
 func TestBuiltinFlags(t *testing.T) {
 	// 测试帮助标志 (中文)
 	t.Run("HelpFlag_Chinese", func(t *testing.T) {
-		defer func() {
-			if r := recover(); r == nil {
-				t.Error("Expected panic due to os.Exit, but got none")
-			}
-		}()
-
 		cmd := cmd.NewCmd("test", "t", types.ContinueOnError)
 		cmd.SetDesc("测试命令")
 		cmd.SetChinese(true)
 
 		// 解析帮助标志
 		err := cmd.Parse([]string{"--help"})
-		// 由于帮助标志会调用os.Exit, 这里应该不会执行到
-		// 所以我们检查错误类型
-		if err == nil {
-			t.Error("Expected error due to os.Exit, but got nil")
+		// 帮助标志处理后返回哨兵错误, 不退出程序
+		if !errors.Is(err, types.ErrHelp) {
+			t.Errorf("Expected types.ErrHelp, got %v", err)
 		}
 	})
 
 	// 测试帮助标志 (英文)
 	t.Run("HelpFlag_English", func(t *testing.T) {
-		defer func() {
-			if r := recover(); r == nil {
-				t.Error("Expected panic due to os.Exit, but got none")
-			}
-		}()
-
 		cmd := cmd.NewCmd("test", "t", types.ContinueOnError)
 		cmd.SetDesc("Test command")
 		cmd.SetChinese(false)
 
 		// 解析帮助标志
 		err := cmd.Parse([]string{"-h"})
-		// 由于帮助标志会调用os.Exit, 这里应该不会执行到
-		// 所以我们检查错误类型
-		if err == nil {
-			t.Error("Expected error due to os.Exit, but got nil")
+		// 帮助标志处理后返回哨兵错误, 不退出程序
+		if !errors.Is(err, types.ErrHelp) {
+			t.Errorf("Expected types.ErrHelp, got %v", err)
 		}
 	})
 
 	// 测试版本标志 (有版本信息)
 	t.Run("VersionFlag_WithVersion", func(t *testing.T) {
-		defer func() {
-			if r := recover(); r == nil {
-				t.Error("Expected panic due to os.Exit, but got none")
-			}
-		}()
-
 		cmd := cmd.NewCmd("test", "t", types.ContinueOnError)
 		cmd.SetDesc("测试命令")
 		cmd.SetVersion("1.0.0")
@@ -574,10 +557,9 @@ func TestBuiltinFlags(t *testing.T) {
 
 		// 解析版本标志
 		err := cmd.Parse([]string{"--version"})
-		// 由于版本标志会调用os.Exit, 这里应该不会执行到
-		// 所以我们检查错误类型
-		if err == nil {
-			t.Error("Expected error due to os.Exit, but got nil")
+		// 版本标志处理后返回哨兵错误, 不退出程序
+		if !errors.Is(err, types.ErrVersion) {
+			t.Errorf("Expected types.ErrVersion, got %v", err)
 		}
 	})
 
@@ -676,3 +658,62 @@ func TestBuiltinFlags(t *testing.T) {
 		}
 	})
 }
+
+// TestCmdOutputWriters 测试内置输出写入命令设置的输出, 并被子命令继承
+func TestCmdOutputWriters(t *testing.T) {
+	var out, errOut bytes.Buffer
+	root := cmd.NewCmd("app", "", types.ContinueOnError)
+	root.SetVersion("1.2.3")
+	root.SetCompletion(true)
+	root.SetDynamicCompletion(true)
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	sub := cmd.NewCmd("deploy", "", types.ContinueOnError)
+	sub.SetDesc("部署应用")
+	sub.Int("port", "", "端口", 0)
+	if err := root.AddSubCmds(sub); err != nil {
+		t.Fatalf("AddSubCmds error: %v", err)
+	}
+
+	// 子命令的帮助信息写入继承的输出
+	if err := root.Parse([]string{"deploy", "--help"}); !errors.Is(err, types.ErrHelp) {
+		t.Fatalf("Parse --help error = %v", err)
+	}
+	if !strings.Contains(out.String(), "部署应用") {
+		t.Errorf("help not written to Out: %q", out.String())
+	}
+
+	out.Reset()
+	if err := root.Parse([]string{"--version"}); !errors.Is(err, types.ErrVersion) {
+		t.Fatalf("Parse --version error = %v", err)
+	}
+	if out.String() != "1.2.3\n" {
+		t.Errorf("version output = %q", out.String())
+	}
+
+	// 解析错误写入错误输出
+	out.Reset()
+	if err := root.Parse([]string{"deploy", "--port", "abc"}); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if !strings.Contains(errOut.String(), `invalid value "abc"`) {
+		t.Errorf("parse error not written to Err: %q", errOut.String())
+	}
+
+	// __complete 协议输出
+	out.Reset()
+	if err := root.ParseAndRoute([]string{types.CompleteCmdName, types.InstructionCandidates, "/"}); err != nil {
+		t.Fatalf("__complete error: %v", err)
+	}
+	if !strings.Contains(out.String(), "deploy") {
+		t.Errorf("__complete output = %q", out.String())
+	}
+
+	// 子命令可以覆盖继承的输出
+	var subOut bytes.Buffer
+	sub.SetOut(&subOut)
+	sub.PrintHelp()
+	if subOut.Len() == 0 || sub.In() != os.Stdin {
+		t.Errorf("sub Out override failed")
+	}
+}